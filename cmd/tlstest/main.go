@@ -0,0 +1,85 @@
+// Command tlstest is an ad-hoc CLI for verifying that HttpCall's TLS/HTTP2
+// fingerprint presets actually produce the fingerprint they claim to, by
+// sending a request to a checker service and diffing the result.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+
+	"httpcall/internal/tlsfp"
+)
+
+func main() {
+	var (
+		presetID  = flag.String("preset", "chrome120", "named TLS/HTTP2 preset to verify (see internal/tlsfp.Presets)")
+		ja3       = flag.String("ja3", "", "custom JA3 string, overrides -preset")
+		akamai    = flag.String("akamai", "", "custom Akamai fingerprint string, overrides -preset")
+		target    = flag.String("target", "https://tls.peet.ws/api/all", "checker URL that echoes back the observed fingerprint")
+		checkFile = flag.String("check", "", "path to a recorded checker JSON response; skips the network call and replays it")
+	)
+	flag.Parse()
+
+	intendedJA3, intendedAkamai := *ja3, *akamai
+	if intendedJA3 == "" || intendedAkamai == "" {
+		preset, ok := tlsfp.Lookup(*presetID)
+		if !ok {
+			fmt.Fprintf(os.Stderr, "tlstest: unknown preset %q\n", *presetID)
+			os.Exit(2)
+		}
+		if intendedJA3 == "" {
+			intendedJA3 = preset.JA3
+		}
+		if intendedAkamai == "" {
+			intendedAkamai = preset.Akamai
+		}
+	}
+
+	body, err := readBody(*checkFile, *target)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "tlstest: %v\n", err)
+		os.Exit(1)
+	}
+
+	observed, err := tlsfp.ParsePeetResponse(body)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "tlstest: parsing checker response: %v\n", err)
+		os.Exit(1)
+	}
+
+	result := tlsfp.Compare(intendedJA3, "", intendedAkamai, observed)
+	printResult(result)
+	if !result.Pass() {
+		os.Exit(1)
+	}
+}
+
+// readBody returns the checker response body, either replayed from a
+// recorded file (-check) or fetched live from target.
+func readBody(checkFile, target string) ([]byte, error) {
+	if checkFile != "" {
+		return os.ReadFile(checkFile)
+	}
+	resp, err := http.Get(target)
+	if err != nil {
+		return nil, fmt.Errorf("fetching %s: %w", target, err)
+	}
+	defer resp.Body.Close()
+	return io.ReadAll(resp.Body)
+}
+
+func printResult(r tlsfp.Result) {
+	fmt.Printf("JA3:    %s  observed=%s\n", status(r.JA3Match), r.Observed.TLS.JA3)
+	fmt.Printf("JA4:    observed=%s\n", r.Observed.TLS.JA4)
+	fmt.Printf("Akamai: %s  observed=%s\n", status(r.AkamaiMatch), r.Observed.HTTP2.AkamaiFingerprint)
+}
+
+func status(ok bool) string {
+	if ok {
+		return "PASS"
+	}
+	return "FAIL"
+}
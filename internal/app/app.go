@@ -0,0 +1,70 @@
+// Package app implements the Wails-bound App struct: the methods exposed
+// here are callable directly from the frontend.
+package app
+
+import (
+	"context"
+	"sync"
+
+	"httpcall/internal/httpclient"
+)
+
+// App is the Wails application binding. Its exported methods are the entire
+// surface the frontend can call into.
+type App struct {
+	ctx context.Context
+
+	clientOnce   sync.Once
+	client       *httpclient.Client
+	dedup        singleflightGroup
+	dedupEnabled bool
+
+	bodiesOnce sync.Once
+	bodies     *bodyStore
+
+	cancelsMu sync.Mutex
+	cancels   map[string]context.CancelFunc
+
+	// emitEvent, when set, is called to push a named event out to the
+	// frontend (Wails' runtime.EventsEmit, in the real binding). Left nil
+	// by default so App stays usable - and its progress-reporting methods
+	// testable - without a live Wails runtime behind a.ctx.
+	emitEvent func(ctx context.Context, eventName string, data any)
+
+	// saveFileDialog, when set, prompts the user for a save path (Wails'
+	// runtime.SaveFileDialog, in the real binding) - used by DownloadToFile
+	// when the caller leaves destPath empty. Left nil by default for the
+	// same reason as emitEvent: App stays usable, and testable, without a
+	// live Wails runtime behind a.ctx.
+	saveFileDialog func(ctx context.Context, suggestedName string) (string, error)
+
+	// sessionTicketCache is set by EnablePersistentSessionTickets, so
+	// SaveSessionTickets has something to flush to disk.
+	sessionTicketCache *httpclient.FileSessionTicketCache
+}
+
+// SetEventEmitter installs the function App uses to push events to the
+// frontend. Startup wires this to Wails' runtime.EventsEmit in production;
+// tests can install a recorder instead.
+func (a *App) SetEventEmitter(emit func(ctx context.Context, eventName string, data any)) {
+	a.emitEvent = emit
+}
+
+// SetSaveFileDialog installs the function App uses to prompt for a save
+// path when a caller (e.g. DownloadToFile) needs one but wasn't given it.
+// Startup wires this to Wails' runtime.SaveFileDialog in production; tests
+// can install a fake instead.
+func (a *App) SetSaveFileDialog(dialog func(ctx context.Context, suggestedName string) (string, error)) {
+	a.saveFileDialog = dialog
+}
+
+// NewApp creates a new App.
+func NewApp() *App {
+	return &App{}
+}
+
+// Startup is called by Wails when the app starts, giving App the runtime
+// context it needs for Wails API calls (dialogs, events, etc.).
+func (a *App) Startup(ctx context.Context) {
+	a.ctx = ctx
+}
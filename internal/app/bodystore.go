@@ -0,0 +1,81 @@
+package app
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// bodyStore retains response bodies on disk, keyed by request ID, so large
+// bodies don't have to live in process memory just to support windowed
+// reads from the UI.
+type bodyStore struct {
+	mu    sync.Mutex
+	dir   string
+	files map[string]string // requestID -> file path
+}
+
+func newBodyStore() *bodyStore {
+	return &bodyStore{files: make(map[string]string)}
+}
+
+func (s *bodyStore) put(requestID string, body []byte) error {
+	if requestID == "" {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.dir == "" {
+		dir, err := os.MkdirTemp("", "httpcall-bodies-*")
+		if err != nil {
+			return err
+		}
+		s.dir = dir
+	}
+
+	path := filepath.Join(s.dir, requestID)
+	if err := os.WriteFile(path, body, 0o600); err != nil {
+		return err
+	}
+	s.files[requestID] = path
+	return nil
+}
+
+// page returns the window [offset, offset+length) of the body stored for
+// requestID. Out-of-range offsets yield an empty slice; an over-long length
+// is clamped to what remains.
+func (s *bodyStore) page(requestID string, offset, length int64) ([]byte, error) {
+	s.mu.Lock()
+	path, ok := s.files[requestID]
+	s.mu.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("no stored body for request %q", requestID)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if offset < 0 || length <= 0 || offset >= info.Size() {
+		return []byte{}, nil
+	}
+	if offset+length > info.Size() {
+		length = info.Size() - offset
+	}
+
+	buf := make([]byte, length)
+	n, err := f.ReadAt(buf, offset)
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+	return buf[:n], nil
+}
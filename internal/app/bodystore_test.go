@@ -0,0 +1,51 @@
+package app
+
+import "testing"
+
+func TestBodyStorePageExtractsWindow(t *testing.T) {
+	s := newBodyStore()
+	if err := s.put("req-1", []byte("0123456789")); err != nil {
+		t.Fatalf("put: %v", err)
+	}
+
+	page, err := s.page("req-1", 3, 4)
+	if err != nil {
+		t.Fatalf("page: %v", err)
+	}
+	if string(page) != "3456" {
+		t.Errorf("page = %q, want %q", page, "3456")
+	}
+}
+
+func TestBodyStorePageClampsLength(t *testing.T) {
+	s := newBodyStore()
+	_ = s.put("req-1", []byte("0123456789"))
+
+	page, err := s.page("req-1", 8, 100)
+	if err != nil {
+		t.Fatalf("page: %v", err)
+	}
+	if string(page) != "89" {
+		t.Errorf("page = %q, want %q", page, "89")
+	}
+}
+
+func TestBodyStorePageOutOfRangeOffset(t *testing.T) {
+	s := newBodyStore()
+	_ = s.put("req-1", []byte("0123456789"))
+
+	page, err := s.page("req-1", 100, 5)
+	if err != nil {
+		t.Fatalf("page: %v", err)
+	}
+	if len(page) != 0 {
+		t.Errorf("page = %q, want empty", page)
+	}
+}
+
+func TestBodyStorePageUnknownRequest(t *testing.T) {
+	s := newBodyStore()
+	if _, err := s.page("missing", 0, 5); err == nil {
+		t.Error("page for unknown request should error")
+	}
+}
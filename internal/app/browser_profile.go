@@ -0,0 +1,66 @@
+package app
+
+import (
+	"encoding/json"
+
+	"httpcall/internal/tlsfp"
+)
+
+// BrowserProfile is a complete, internally consistent set of request
+// defaults modeled on a given browser: its JA3/Akamai fingerprint strings
+// (self-reported metadata - see tlsfp.Preset's doc comment; this does not
+// alter the live TLS handshake), its canonical header order, and its
+// default header values. Setting these independently is how most mismatches
+// between the self-reported fingerprint and the actual request happen;
+// ApplyBrowserProfile builds them together.
+type BrowserProfile struct {
+	BrowserID   string            `json:"browserId"`
+	JA3         string            `json:"ja3"`
+	Akamai      string            `json:"akamai"`
+	HeaderOrder []string          `json:"headerOrder"`
+	Headers     map[string]string `json:"headers"`
+}
+
+var headerOrders = map[string][]string{
+	"chrome120":  {"sec-ch-ua", "sec-ch-ua-mobile", "sec-ch-ua-platform", "upgrade-insecure-requests", "user-agent", "accept", "origin", "sec-fetch-site", "sec-fetch-mode", "sec-fetch-user", "sec-fetch-dest", "referer", "accept-encoding", "accept-language"},
+	"firefox120": {"user-agent", "accept", "accept-language", "accept-encoding", "origin", "upgrade-insecure-requests", "sec-fetch-dest", "sec-fetch-mode", "sec-fetch-site", "sec-fetch-user", "referer"},
+}
+
+var defaultHeaders = map[string]map[string]string{
+	"chrome120": {
+		"user-agent":         "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36",
+		"accept":             "text/html,application/xhtml+xml,application/xml;q=0.9,image/avif,image/webp,*/*;q=0.8",
+		"sec-ch-ua":          `"Not_A Brand";v="8", "Chromium";v="120", "Google Chrome";v="120"`,
+		"sec-ch-ua-mobile":   "?0",
+		"sec-ch-ua-platform": `"Windows"`,
+	},
+	"firefox120": {
+		"user-agent": "Mozilla/5.0 (Windows NT 10.0; Win64; x64; rv:120.0) Gecko/20100101 Firefox/120.0",
+		"accept":     "text/html,application/xhtml+xml,application/xml;q=0.9,image/avif,*/*;q=0.8",
+	},
+}
+
+// ApplyBrowserProfile returns a JSON-encoded BrowserProfile bundling the TLS
+// preset, Akamai fingerprint, header order, and default headers for
+// browserID, so the frontend can apply all four together instead of only
+// setting the JA3. Returns an empty string if browserID is unknown.
+func (a *App) ApplyBrowserProfile(browserID string) string {
+	preset, ok := tlsfp.Lookup(browserID)
+	if !ok {
+		return ""
+	}
+
+	profile := BrowserProfile{
+		BrowserID:   browserID,
+		JA3:         preset.JA3,
+		Akamai:      preset.Akamai,
+		HeaderOrder: headerOrders[browserID],
+		Headers:     defaultHeaders[browserID],
+	}
+
+	out, err := json.Marshal(profile)
+	if err != nil {
+		return ""
+	}
+	return string(out)
+}
@@ -0,0 +1,36 @@
+package app
+
+import (
+	"encoding/json"
+	"testing"
+
+	"httpcall/internal/tlsfp"
+)
+
+func TestApplyBrowserProfileMatchesPreset(t *testing.T) {
+	a := NewApp()
+	out := a.ApplyBrowserProfile("chrome120")
+	if out == "" {
+		t.Fatal("ApplyBrowserProfile returned empty string for known browser")
+	}
+
+	var profile BrowserProfile
+	if err := json.Unmarshal([]byte(out), &profile); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+
+	preset := tlsfp.Presets["chrome120"]
+	if profile.JA3 != preset.JA3 {
+		t.Errorf("JA3 = %q, want %q", profile.JA3, preset.JA3)
+	}
+	if profile.Akamai != preset.Akamai {
+		t.Errorf("Akamai = %q, want %q", profile.Akamai, preset.Akamai)
+	}
+}
+
+func TestApplyBrowserProfileUnknownBrowser(t *testing.T) {
+	a := NewApp()
+	if out := a.ApplyBrowserProfile("does-not-exist"); out != "" {
+		t.Errorf("ApplyBrowserProfile(unknown) = %q, want empty", out)
+	}
+}
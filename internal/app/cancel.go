@@ -0,0 +1,55 @@
+package app
+
+import (
+	"context"
+
+	"httpcall/internal/httpclient"
+)
+
+// SendRequestWithID behaves like SendRequest (no dedup, since a cancelable
+// request is inherently a one-off rather than something to share with
+// concurrent identical calls), but runs cfg under a context registered
+// under id so a later CancelRequest(id) can abort it mid-flight. The
+// registration is removed once the request finishes - successfully,
+// with an error, or canceled - so the map never retains more than the
+// requests actually in flight.
+func (a *App) SendRequestWithID(id string, cfg httpclient.RequestConfig) string {
+	ctx, cancel := context.WithCancel(context.Background())
+	a.registerCancel(id, cancel)
+	defer a.unregisterCancel(id)
+	defer cancel()
+
+	return a.sendWithContext(ctx, cfg)
+}
+
+// CancelRequest aborts the in-flight request registered under id (see
+// SendRequestWithID). Client.Send's underlying call is context-aware, so
+// canceling unblocks it immediately and the transport closes the
+// connection rather than waiting out the response. Returns whether a
+// request was actually found under id; canceling an unknown or already
+// finished id is a no-op.
+func (a *App) CancelRequest(id string) bool {
+	a.cancelsMu.Lock()
+	cancel, ok := a.cancels[id]
+	a.cancelsMu.Unlock()
+	if !ok {
+		return false
+	}
+	cancel()
+	return true
+}
+
+func (a *App) registerCancel(id string, cancel context.CancelFunc) {
+	a.cancelsMu.Lock()
+	defer a.cancelsMu.Unlock()
+	if a.cancels == nil {
+		a.cancels = make(map[string]context.CancelFunc)
+	}
+	a.cancels[id] = cancel
+}
+
+func (a *App) unregisterCancel(id string) {
+	a.cancelsMu.Lock()
+	defer a.cancelsMu.Unlock()
+	delete(a.cancels, id)
+}
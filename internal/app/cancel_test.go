@@ -0,0 +1,75 @@
+package app
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"httpcall/internal/httpclient"
+)
+
+func TestCancelRequestAbortsInFlightRequest(t *testing.T) {
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+	defer close(release)
+
+	a := NewApp()
+	var out string
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		out = a.SendRequestWithID("req-1", httpclient.RequestConfig{Method: "GET", URL: srv.URL})
+	}()
+
+	deadline := time.After(time.Second)
+	for {
+		if a.CancelRequest("req-1") {
+			break
+		}
+		select {
+		case <-deadline:
+			t.Fatal("timed out waiting for req-1 to register")
+		default:
+			time.Sleep(time.Millisecond)
+		}
+	}
+	wg.Wait()
+
+	var result map[string]string
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if !strings.Contains(result["error"], "context canceled") {
+		t.Errorf("error = %q, want it to mention context canceled", result["error"])
+	}
+}
+
+func TestCancelRequestUnknownIDIsNoop(t *testing.T) {
+	a := NewApp()
+	if a.CancelRequest("does-not-exist") {
+		t.Error("CancelRequest(unknown) = true, want false")
+	}
+}
+
+func TestSendRequestWithIDCleansUpAfterCompletion(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	a := NewApp()
+	a.SendRequestWithID("req-2", httpclient.RequestConfig{Method: "GET", URL: srv.URL})
+
+	if a.CancelRequest("req-2") {
+		t.Error("CancelRequest found a registration after the request already completed, want it cleaned up")
+	}
+}
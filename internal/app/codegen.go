@@ -0,0 +1,217 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"httpcall/internal/httpclient"
+)
+
+// CodeGenTarget names a language/library GenerateCode can render a request
+// as. Fingerprint-related RequestConfig.Tls settings (JA3, Akamai, custom
+// ALPN, ...) have no equivalent in any of these - they're a property of
+// the TLS stack actually making the connection, which none of these
+// snippets control. When the request set a JA3, Akamai, or CustomJA4
+// fingerprint, GenerateCode notes that in a comment rather than silently
+// dropping it; other TLS settings (such as ALPN alone) get no note.
+type CodeGenTarget string
+
+const (
+	CodeGenFetch          CodeGenTarget = "fetch"
+	CodeGenAxios          CodeGenTarget = "axios"
+	CodeGenPythonRequests CodeGenTarget = "python-requests"
+	CodeGenGo             CodeGenTarget = "go"
+	CodeGenCurl           CodeGenTarget = "curl"
+)
+
+// GenerateCode renders cfg as a runnable snippet for target, for users who
+// want to take a request built in the UI back into their own codebase.
+// Returns "" for an unrecognized target.
+func (a *App) GenerateCode(cfg httpclient.RequestConfig, target string) string {
+	switch CodeGenTarget(target) {
+	case CodeGenFetch:
+		return generateFetch(cfg)
+	case CodeGenAxios:
+		return generateAxios(cfg)
+	case CodeGenPythonRequests:
+		return generatePythonRequests(cfg)
+	case CodeGenGo:
+		return generateGo(cfg)
+	case CodeGenCurl:
+		return generateCurl(cfg)
+	default:
+		return ""
+	}
+}
+
+// ExportAsCurl renders cfg as a curl invocation - a thin alias for
+// GenerateCode(cfg, "curl") under its own name, since "copy as curl" is a
+// common enough action in HTTP tooling to deserve a dedicated binding
+// rather than making the frontend pass a target string for it.
+func (a *App) ExportAsCurl(cfg httpclient.RequestConfig) string {
+	return generateCurl(cfg)
+}
+
+// fingerprintNote returns a comment (in the given line-comment syntax)
+// warning that target can't reproduce cfg's TLS fingerprint, or "" if cfg
+// didn't configure one.
+func fingerprintNote(cfg httpclient.RequestConfig, lineComment string) string {
+	if cfg.Tls == nil || (cfg.Tls.JA3 == "" && cfg.Tls.Akamai == "" && cfg.Tls.CustomJA4 == "") {
+		return ""
+	}
+	return lineComment + " Note: this request configured a TLS fingerprint (JA3/Akamai/JA4); " +
+		"this snippet's HTTP client uses its runtime's normal TLS stack and cannot reproduce it.\n"
+}
+
+func generateFetch(cfg httpclient.RequestConfig) string {
+	var b strings.Builder
+	b.WriteString(fingerprintNote(cfg, "//"))
+	b.WriteString(fmt.Sprintf("fetch(%q, {\n", cfg.URL))
+	b.WriteString(fmt.Sprintf("  method: %q,\n", cfg.Method))
+	if len(cfg.Headers) > 0 {
+		b.WriteString("  headers: {\n")
+		for _, h := range cfg.Headers {
+			b.WriteString(fmt.Sprintf("    %q: %q,\n", h.Name, h.Value))
+		}
+		b.WriteString("  },\n")
+	}
+	if cfg.Body != "" {
+		b.WriteString(fmt.Sprintf("  body: %q,\n", cfg.Body))
+	}
+	b.WriteString("})\n")
+	return b.String()
+}
+
+func generateAxios(cfg httpclient.RequestConfig) string {
+	var b strings.Builder
+	b.WriteString(fingerprintNote(cfg, "//"))
+	b.WriteString("axios({\n")
+	b.WriteString(fmt.Sprintf("  method: %q,\n", cfg.Method))
+	b.WriteString(fmt.Sprintf("  url: %q,\n", cfg.URL))
+	if len(cfg.Headers) > 0 {
+		b.WriteString("  headers: {\n")
+		for _, h := range cfg.Headers {
+			b.WriteString(fmt.Sprintf("    %q: %q,\n", h.Name, h.Value))
+		}
+		b.WriteString("  },\n")
+	}
+	if cfg.Body != "" {
+		b.WriteString(fmt.Sprintf("  data: %q,\n", cfg.Body))
+	}
+	b.WriteString("})\n")
+	return b.String()
+}
+
+func generatePythonRequests(cfg httpclient.RequestConfig) string {
+	var b strings.Builder
+	b.WriteString(fingerprintNote(cfg, "#"))
+	b.WriteString("import requests\n\n")
+	b.WriteString("response = requests.request(\n")
+	b.WriteString(fmt.Sprintf("    %q,\n", cfg.Method))
+	b.WriteString(fmt.Sprintf("    %q,\n", cfg.URL))
+	if len(cfg.Headers) > 0 {
+		b.WriteString("    headers={\n")
+		for _, h := range cfg.Headers {
+			b.WriteString(fmt.Sprintf("        %q: %q,\n", h.Name, h.Value))
+		}
+		b.WriteString("    },\n")
+	}
+	if cfg.Body != "" {
+		b.WriteString(fmt.Sprintf("    data=%q,\n", cfg.Body))
+	}
+	b.WriteString(")\n")
+	return b.String()
+}
+
+// shellQuote wraps s in single quotes for safe use as one POSIX shell
+// argument, the way every "copy as curl" tool does it - single quotes
+// disable all shell interpretation except for the quote character itself,
+// which can't be embedded in a single-quoted string and must instead close
+// the quote, emit an escaped literal quote, then reopen it.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// formDataCurlField renders one FormDataField as curl -F's
+// "name=value"/"name=@path;filename=...;type=..." argument syntax.
+func formDataCurlField(f httpclient.FormDataField) string {
+	if f.FilePath != "" {
+		part := f.Name + "=@" + f.FilePath
+		if f.Filename != "" {
+			part += ";filename=" + f.Filename
+		}
+		if f.ContentType != "" {
+			part += ";type=" + f.ContentType
+		}
+		return part
+	}
+	return f.Name + "=" + f.Value
+}
+
+// generateCurl renders cfg as a curl command line. Authentication
+// (basic/bearer/API key) has no dedicated RequestConfig field in this
+// codebase - it's just another header the caller set - so it's reproduced
+// via the same -H flags as any other header rather than curl's --user/
+// --oauth2-bearer conveniences. Likewise there's no per-request proxy
+// config to translate into curl's -x, so no proxy flag is emitted.
+func generateCurl(cfg httpclient.RequestConfig) string {
+	var b strings.Builder
+	if note := fingerprintNote(cfg, "#"); note != "" {
+		b.WriteString(note)
+	}
+	b.WriteString("curl -X ")
+	b.WriteString(shellQuote(cfg.Method))
+
+	b.WriteString(" \\\n  ")
+	b.WriteString(shellQuote(cfg.URL))
+
+	for _, h := range cfg.Headers {
+		b.WriteString(" \\\n  -H ")
+		b.WriteString(shellQuote(h.Name + ": " + h.Value))
+	}
+
+	switch {
+	case cfg.BodyFilePath != "":
+		b.WriteString(" \\\n  --data-binary ")
+		b.WriteString(shellQuote("@" + cfg.BodyFilePath))
+	case len(cfg.FormData) > 0:
+		for _, f := range cfg.FormData {
+			b.WriteString(" \\\n  -F ")
+			b.WriteString(shellQuote(formDataCurlField(f)))
+		}
+	case cfg.BodyIsBase64:
+		// The substitution must sit outside any single quotes - those
+		// disable command substitution along with everything else - so
+		// it's wrapped in double quotes instead, with only the base64
+		// payload itself (passed to printf as its own argument) single-
+		// quoted via shellQuote.
+		b.WriteString(" \\\n  --data-binary ")
+		b.WriteString(`"$(printf '%s' ` + shellQuote(cfg.Body) + ` | base64 -d)"`)
+	case cfg.Body != "":
+		b.WriteString(" \\\n  --data-raw ")
+		b.WriteString(shellQuote(cfg.Body))
+	}
+
+	b.WriteString("\n")
+	return b.String()
+}
+
+func generateGo(cfg httpclient.RequestConfig) string {
+	var b strings.Builder
+	b.WriteString(fingerprintNote(cfg, "//"))
+	b.WriteString("package main\n\n")
+	b.WriteString("import (\n\t\"net/http\"\n\t\"strings\"\n)\n\n")
+	b.WriteString("func main() {\n")
+	body := "nil"
+	if cfg.Body != "" {
+		body = fmt.Sprintf("strings.NewReader(%q)", cfg.Body)
+	}
+	b.WriteString(fmt.Sprintf("\treq, _ := http.NewRequest(%q, %q, %s)\n", cfg.Method, cfg.URL, body))
+	for _, h := range cfg.Headers {
+		b.WriteString(fmt.Sprintf("\treq.Header.Add(%q, %q)\n", h.Name, h.Value))
+	}
+	b.WriteString("\tresp, _ := http.DefaultClient.Do(req)\n")
+	b.WriteString("\tdefer resp.Body.Close()\n")
+	b.WriteString("}\n")
+	return b.String()
+}
@@ -0,0 +1,185 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"httpcall/internal/httpclient"
+)
+
+func TestGenerateCodeFetchIncludesMethodHeadersAndBody(t *testing.T) {
+	a := NewApp()
+	cfg := httpclient.RequestConfig{
+		Method: "POST",
+		URL:    "https://example.com/api",
+		Headers: []httpclient.HeaderField{
+			{Name: "Authorization", Value: "Bearer token123"},
+			{Name: "Content-Type", Value: "application/json"},
+		},
+		Body: `{"hello":"world"}`,
+	}
+	out := a.GenerateCode(cfg, "fetch")
+
+	for _, want := range []string{
+		`fetch("https://example.com/api"`,
+		`method: "POST"`,
+		`"Authorization": "Bearer token123"`,
+		`"Content-Type": "application/json"`,
+		`\"hello\":\"world\"`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("GenerateCode(fetch) missing %q in:\n%s", want, out)
+		}
+	}
+}
+
+func TestGenerateCodePythonRequestsIncludesMethodHeadersAndBody(t *testing.T) {
+	a := NewApp()
+	cfg := httpclient.RequestConfig{
+		Method:  "PUT",
+		URL:     "https://example.com/api",
+		Headers: []httpclient.HeaderField{{Name: "Authorization", Value: "Bearer token123"}},
+		Body:    `{"hello":"world"}`,
+	}
+	out := a.GenerateCode(cfg, "python-requests")
+
+	for _, want := range []string{
+		"import requests",
+		`"PUT"`,
+		`"https://example.com/api"`,
+		`"Authorization": "Bearer token123"`,
+		`\"hello\":\"world\"`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("GenerateCode(python-requests) missing %q in:\n%s", want, out)
+		}
+	}
+}
+
+func TestGenerateCodeNotesFingerprintLimitation(t *testing.T) {
+	a := NewApp()
+	cfg := httpclient.RequestConfig{
+		Method: "GET",
+		URL:    "https://example.com",
+		Tls:    &httpclient.TlsConfig{JA3: "771,4865-4866,0-23,29-23-24,0"},
+	}
+	out := a.GenerateCode(cfg, "fetch")
+	if !strings.Contains(out, "fingerprint") {
+		t.Errorf("GenerateCode(fetch) with a TLS fingerprint configured should note the limitation, got:\n%s", out)
+	}
+}
+
+func TestGenerateCodeCurlIncludesMethodURLHeadersAndBody(t *testing.T) {
+	a := NewApp()
+	cfg := httpclient.RequestConfig{
+		Method:  "POST",
+		URL:     "https://example.com/api",
+		Headers: []httpclient.HeaderField{{Name: "Authorization", Value: "Bearer token123"}},
+		Body:    `{"hello":"world"}`,
+	}
+	out := a.GenerateCode(cfg, "curl")
+
+	for _, want := range []string{
+		"curl -X 'POST'",
+		"'https://example.com/api'",
+		"-H 'Authorization: Bearer token123'",
+		`--data-raw '{"hello":"world"}'`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("GenerateCode(curl) missing %q in:\n%s", want, out)
+		}
+	}
+}
+
+func TestGenerateCodeCurlQuotesEmbeddedSingleQuotes(t *testing.T) {
+	a := NewApp()
+	cfg := httpclient.RequestConfig{
+		Method: "GET",
+		URL:    "https://example.com",
+		Body:   "it's a body",
+	}
+	out := a.GenerateCode(cfg, "curl")
+	if !strings.Contains(out, `--data-raw 'it'\''s a body'`) {
+		t.Errorf("GenerateCode(curl) did not escape an embedded single quote, got:\n%s", out)
+	}
+}
+
+func TestGenerateCodeCurlWithBodyFilePathUsesDataBinary(t *testing.T) {
+	a := NewApp()
+	cfg := httpclient.RequestConfig{
+		Method:       "PUT",
+		URL:          "https://example.com/upload",
+		BodyFilePath: "/tmp/payload.bin",
+	}
+	out := a.GenerateCode(cfg, "curl")
+	if !strings.Contains(out, "--data-binary '@/tmp/payload.bin'") {
+		t.Errorf("GenerateCode(curl) missing file body flag, got:\n%s", out)
+	}
+}
+
+func TestGenerateCodeCurlWithFormDataUsesFlags(t *testing.T) {
+	a := NewApp()
+	cfg := httpclient.RequestConfig{
+		Method: "POST",
+		URL:    "https://example.com/upload",
+		FormData: []httpclient.FormDataField{
+			{Name: "title", Value: "my title"},
+			{Name: "file", FilePath: "/tmp/photo.png", Filename: "photo.png", ContentType: "image/png"},
+		},
+	}
+	out := a.GenerateCode(cfg, "curl")
+	for _, want := range []string{
+		"-F 'title=my title'",
+		"-F 'file=@/tmp/photo.png;filename=photo.png;type=image/png'",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("GenerateCode(curl) missing %q in:\n%s", want, out)
+		}
+	}
+}
+
+func TestGenerateCodeCurlWithBase64BodyDecodesBeforeSending(t *testing.T) {
+	a := NewApp()
+	cfg := httpclient.RequestConfig{
+		Method:       "POST",
+		URL:          "https://example.com/upload",
+		Body:         "aGVsbG8=",
+		BodyIsBase64: true,
+	}
+	out := a.GenerateCode(cfg, "curl")
+	if !strings.Contains(out, `--data-binary "$(printf '%s' 'aGVsbG8=' | base64 -d)"`) {
+		t.Errorf("GenerateCode(curl) missing base64 decode substitution, got:\n%s", out)
+	}
+}
+
+func TestGenerateCodeCurlNotesFingerprintLimitation(t *testing.T) {
+	a := NewApp()
+	cfg := httpclient.RequestConfig{
+		Method: "GET",
+		URL:    "https://example.com",
+		Tls:    &httpclient.TlsConfig{JA3: "771,4865-4866,0-23,29-23-24,0"},
+	}
+	out := a.GenerateCode(cfg, "curl")
+	if !strings.Contains(out, "fingerprint") {
+		t.Errorf("GenerateCode(curl) with a TLS fingerprint configured should note the limitation, got:\n%s", out)
+	}
+}
+
+func TestExportAsCurlMatchesGenerateCode(t *testing.T) {
+	a := NewApp()
+	cfg := httpclient.RequestConfig{
+		Method: "GET",
+		URL:    "https://example.com",
+	}
+	if got, want := a.ExportAsCurl(cfg), a.GenerateCode(cfg, "curl"); got != want {
+		t.Errorf("ExportAsCurl() = %q, want %q", got, want)
+	}
+}
+
+func TestGenerateCodeUnknownTargetReturnsEmpty(t *testing.T) {
+	a := NewApp()
+	out := a.GenerateCode(httpclient.RequestConfig{Method: "GET", URL: "https://example.com"}, "ruby")
+	if out != "" {
+		t.Errorf("GenerateCode(ruby) = %q, want empty", out)
+	}
+}
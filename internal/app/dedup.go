@@ -0,0 +1,46 @@
+package app
+
+import "sync"
+
+// singleflightGroup collapses concurrent calls that share the same key into
+// a single execution, with every caller receiving that one result. It is a
+// hand-rolled version of golang.org/x/sync/singleflight's Group, kept local
+// to avoid pulling in the dependency for one call site.
+type singleflightGroup struct {
+	mu    sync.Mutex
+	calls map[string]*inflightCall
+}
+
+type inflightCall struct {
+	wg  sync.WaitGroup
+	val string
+	err error
+}
+
+// Do executes fn for key, or waits for and reuses the result of an
+// already-running call for the same key.
+func (g *singleflightGroup) Do(key string, fn func() (string, error)) (string, error) {
+	g.mu.Lock()
+	if g.calls == nil {
+		g.calls = make(map[string]*inflightCall)
+	}
+	if call, ok := g.calls[key]; ok {
+		g.mu.Unlock()
+		call.wg.Wait()
+		return call.val, call.err
+	}
+
+	call := &inflightCall{}
+	call.wg.Add(1)
+	g.calls[key] = call
+	g.mu.Unlock()
+
+	call.val, call.err = fn()
+	call.wg.Done()
+
+	g.mu.Lock()
+	delete(g.calls, key)
+	g.mu.Unlock()
+
+	return call.val, call.err
+}
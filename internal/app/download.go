@@ -0,0 +1,110 @@
+package app
+
+import (
+	"context"
+	"encoding/base64"
+	"net/url"
+	"os"
+	"path"
+
+	"httpcall/internal/httpclient"
+)
+
+// downloadProgressEvent is the name emitted (via a.emitEvent) as a download
+// streams to disk.
+const downloadProgressEvent = "download:progress"
+
+// bodyChunkEvent is the name emitted (via a.emitEvent) as SendRequestStream
+// receives each chunk of a response body.
+const bodyChunkEvent = "send:chunk"
+
+// bodyChunk is the event payload SendRequestStream emits per chunk.
+type bodyChunk struct {
+	RequestID string `json:"requestId"`
+	Data      string `json:"data"` // base64-encoded chunk bytes
+}
+
+// SendRequestStream behaves like SendRequest, but streams the response body
+// to the frontend as a series of bodyChunkEvent events instead of buffering
+// the whole thing and returning it inline - the right choice for large
+// responses a UI wants to show progress on without the memory spike of
+// SendRequest's buffer-then-return-it-all approach. Returns the
+// JSON-encoded httpclient.ResponseData (its Body field always empty; body
+// bytes arrive via events keyed by cfg.RequestID) or {"error": "..."} on
+// failure.
+func (a *App) SendRequestStream(cfg httpclient.RequestConfig) string {
+	a.clientOnce.Do(func() { a.client = httpclient.NewClient() })
+
+	resp, err := a.client.SendStream(context.Background(), cfg, func(chunk []byte) error {
+		if a.emitEvent != nil {
+			a.emitEvent(a.ctx, bodyChunkEvent, bodyChunk{
+				RequestID: cfg.RequestID,
+				Data:      base64.StdEncoding.EncodeToString(chunk),
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return marshalOrEmpty(map[string]string{"error": err.Error()})
+	}
+	return marshalOrEmpty(resp)
+}
+
+// DownloadToFile streams cfg's response body straight to destPath as it
+// arrives, bypassing the webview and the base64/read-all path SendRequest
+// uses - the right choice for large files, where buffering the whole body
+// in memory (and round-tripping it through the UI) is wasteful. Progress is
+// reported via the downloadProgressEvent as httpclient.DownloadProgress
+// values. If destPath is empty, a's saveFileDialog (see SetSaveFileDialog)
+// is prompted for one; with no dialog installed, that's an error rather
+// than a silent fallback. Returns the JSON-encoded httpclient.DownloadResult,
+// or {"error": "..."} on failure.
+func (a *App) DownloadToFile(cfg httpclient.RequestConfig, destPath string) string {
+	a.clientOnce.Do(func() { a.client = httpclient.NewClient() })
+
+	if destPath == "" {
+		if a.saveFileDialog == nil {
+			return marshalOrEmpty(map[string]string{"error": "no destination path given and no save dialog is available"})
+		}
+		chosen, err := a.saveFileDialog(a.ctx, suggestedDownloadName(cfg.URL))
+		if err != nil {
+			return marshalOrEmpty(map[string]string{"error": err.Error()})
+		}
+		if chosen == "" {
+			return marshalOrEmpty(map[string]string{"error": "save dialog was canceled"})
+		}
+		destPath = chosen
+	}
+
+	f, err := os.Create(destPath)
+	if err != nil {
+		return marshalOrEmpty(map[string]string{"error": err.Error()})
+	}
+	defer f.Close()
+
+	result, err := a.client.Download(context.Background(), cfg, f, func(p httpclient.DownloadProgress) error {
+		if a.emitEvent != nil {
+			a.emitEvent(a.ctx, downloadProgressEvent, p)
+		}
+		return nil
+	})
+	if err != nil {
+		return marshalOrEmpty(map[string]string{"error": err.Error()})
+	}
+	return marshalOrEmpty(result)
+}
+
+// suggestedDownloadName derives a filename to pre-fill the save dialog with
+// from rawURL's path, falling back to "download" for a URL with no usable
+// path segment (e.g. "https://example.com" or a malformed URL).
+func suggestedDownloadName(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "download"
+	}
+	name := path.Base(u.Path)
+	if name == "" || name == "." || name == "/" {
+		return "download"
+	}
+	return name
+}
@@ -0,0 +1,115 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"httpcall/internal/httpclient"
+)
+
+func TestDownloadToFileWritesBodyAndEmitsProgress(t *testing.T) {
+	body := strings.Repeat("y", 1<<20)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(body))
+	}))
+	defer srv.Close()
+
+	a := NewApp()
+	var events int
+	a.SetEventEmitter(func(ctx context.Context, name string, data any) {
+		if name == downloadProgressEvent {
+			events++
+		}
+	})
+
+	dest := filepath.Join(t.TempDir(), "out.bin")
+	out := a.DownloadToFile(httpclient.RequestConfig{Method: "GET", URL: srv.URL}, dest)
+
+	var result httpclient.DownloadResult
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("unmarshal: %v, out=%s", err, out)
+	}
+	if result.BytesWritten != int64(len(body)) {
+		t.Errorf("BytesWritten = %d, want %d", result.BytesWritten, len(body))
+	}
+	if events == 0 {
+		t.Error("expected at least one progress event")
+	}
+
+	written, err := os.ReadFile(dest)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	if string(written) != body {
+		t.Error("file contents don't match server body")
+	}
+}
+
+func TestDownloadToFileReportsContentType(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "image/png")
+		w.Write([]byte("fake-png-bytes"))
+	}))
+	defer srv.Close()
+
+	a := NewApp()
+	out := a.DownloadToFile(httpclient.RequestConfig{Method: "GET", URL: srv.URL}, filepath.Join(t.TempDir(), "out.png"))
+
+	var result httpclient.DownloadResult
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("unmarshal: %v, out=%s", err, out)
+	}
+	if result.ContentType != "image/png" {
+		t.Errorf("ContentType = %q, want image/png", result.ContentType)
+	}
+}
+
+func TestDownloadToFilePromptsSaveDialogWhenDestPathEmpty(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("data"))
+	}))
+	defer srv.Close()
+
+	a := NewApp()
+	dest := filepath.Join(t.TempDir(), "chosen.bin")
+	var suggested string
+	a.SetSaveFileDialog(func(ctx context.Context, suggestedName string) (string, error) {
+		suggested = suggestedName
+		return dest, nil
+	})
+
+	out := a.DownloadToFile(httpclient.RequestConfig{Method: "GET", URL: srv.URL + "/report.bin"}, "")
+
+	var result httpclient.DownloadResult
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("unmarshal: %v, out=%s", err, out)
+	}
+	if result.BytesWritten != 4 {
+		t.Errorf("BytesWritten = %d, want 4", result.BytesWritten)
+	}
+	if suggested != "report.bin" {
+		t.Errorf("suggested dialog name = %q, want report.bin", suggested)
+	}
+	if _, err := os.Stat(dest); err != nil {
+		t.Errorf("chosen path wasn't written: %v", err)
+	}
+}
+
+func TestDownloadToFileErrorsWithNoDestPathAndNoDialog(t *testing.T) {
+	a := NewApp()
+	out := a.DownloadToFile(httpclient.RequestConfig{Method: "GET", URL: "http://example.com"}, "")
+
+	var result map[string]string
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("unmarshal: %v, out=%s", err, out)
+	}
+	if result["error"] == "" {
+		t.Error("expected an error when destPath is empty and no save dialog is installed")
+	}
+}
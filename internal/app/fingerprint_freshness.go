@@ -0,0 +1,19 @@
+package app
+
+import (
+	"time"
+
+	"httpcall/internal/tlsfp"
+)
+
+// CheckFingerprintFreshness warns when presetID's impersonated browser
+// version is old enough that real browsers have likely auto-updated past
+// it (see tlsfp.FreshnessWarning), making the fingerprint detectable as
+// stale. Returns "" when presetID is unknown or still looks current.
+func (a *App) CheckFingerprintFreshness(presetID string) string {
+	preset, ok := tlsfp.Lookup(presetID)
+	if !ok {
+		return ""
+	}
+	return tlsfp.FreshnessWarning(preset, time.Now())
+}
@@ -0,0 +1,17 @@
+package app
+
+import "testing"
+
+func TestCheckFingerprintFreshnessWarnsForOldPreset(t *testing.T) {
+	a := NewApp()
+	if got := a.CheckFingerprintFreshness("chrome120"); got == "" {
+		t.Error("CheckFingerprintFreshness(chrome120) = empty, want a staleness warning")
+	}
+}
+
+func TestCheckFingerprintFreshnessEmptyForUnknownPreset(t *testing.T) {
+	a := NewApp()
+	if got := a.CheckFingerprintFreshness("does-not-exist"); got != "" {
+		t.Errorf("CheckFingerprintFreshness(unknown) = %q, want empty", got)
+	}
+}
@@ -0,0 +1,34 @@
+package app
+
+import "strings"
+
+// FingerprintFromUserAgent maps ua to the closest known browser preset by
+// family and returns its JA3/Akamai/header-order bundle via
+// ApplyBrowserProfile - for users who only have a target's User-Agent and
+// want a matching fingerprint. Since only one version is registered per
+// family today (see tlsfp.Presets), any version of a known browser falls
+// back to that family's nearest registered preset. Returns "" when ua
+// doesn't match a known browser family at all.
+func (a *App) FingerprintFromUserAgent(ua string) string {
+	presetID := nearestPresetForUserAgent(ua)
+	if presetID == "" {
+		return ""
+	}
+	return a.ApplyBrowserProfile(presetID)
+}
+
+// nearestPresetForUserAgent identifies ua's browser family and returns the
+// nearest registered preset ID for it - "nearest" meaning closest by family
+// today, since chrome120/firefox120 are each the only version registered.
+// Checked in this order because Chromium-based UAs (Edge, Opera, etc) also
+// carry a "Chrome/" token; checking Firefox first avoids misclassifying it.
+func nearestPresetForUserAgent(ua string) string {
+	switch {
+	case strings.Contains(ua, "Firefox/"):
+		return "firefox120"
+	case strings.Contains(ua, "Chrome/"):
+		return "chrome120"
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,50 @@
+package app
+
+import (
+	"encoding/json"
+	"testing"
+
+	"httpcall/internal/tlsfp"
+)
+
+func TestFingerprintFromUserAgentChrome131MapsToChromePreset(t *testing.T) {
+	a := NewApp()
+	ua := "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/131.0.0.0 Safari/537.36"
+	out := a.FingerprintFromUserAgent(ua)
+	if out == "" {
+		t.Fatal("FingerprintFromUserAgent returned empty string for a Chrome UA")
+	}
+
+	var profile BrowserProfile
+	if err := json.Unmarshal([]byte(out), &profile); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	preset := tlsfp.Presets["chrome120"]
+	if profile.JA3 != preset.JA3 {
+		t.Errorf("JA3 = %q, want the chrome120 preset's JA3", profile.JA3)
+	}
+	if profile.Akamai != preset.Akamai {
+		t.Errorf("Akamai = %q, want the chrome120 preset's Akamai", profile.Akamai)
+	}
+}
+
+func TestFingerprintFromUserAgentFirefoxMapsToFirefoxPreset(t *testing.T) {
+	a := NewApp()
+	ua := "Mozilla/5.0 (Windows NT 10.0; Win64; x64; rv:121.0) Gecko/20100101 Firefox/121.0"
+	out := a.FingerprintFromUserAgent(ua)
+
+	var profile BrowserProfile
+	if err := json.Unmarshal([]byte(out), &profile); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if profile.JA3 != tlsfp.Presets["firefox120"].JA3 {
+		t.Errorf("JA3 = %q, want the firefox120 preset's JA3", profile.JA3)
+	}
+}
+
+func TestFingerprintFromUserAgentUnknownBrowserReturnsEmpty(t *testing.T) {
+	a := NewApp()
+	if out := a.FingerprintFromUserAgent("curl/8.0.1"); out != "" {
+		t.Errorf("FingerprintFromUserAgent(curl) = %q, want empty", out)
+	}
+}
@@ -0,0 +1,129 @@
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"strings"
+
+	"httpcall/internal/httpclient"
+)
+
+// forwardedField is the RFC 7239 Forwarded header's individual parameters -
+// only the ones worth spoofing for testing purposes. A Forwarded header can
+// carry several forwarded-pairs (one per proxy hop); this builds just one,
+// which covers the common "pretend this request came from elsewhere"
+// testing case.
+type forwardedField struct {
+	For   string `json:"for,omitempty"`
+	By    string `json:"by,omitempty"`
+	Proto string `json:"proto,omitempty"`
+	Host  string `json:"host,omitempty"`
+}
+
+// forwardedHeadersRequest is the JSON shape BuildForwardedHeaders accepts.
+type forwardedHeadersRequest struct {
+	XForwardedFor []string        `json:"xForwardedFor,omitempty"`
+	XRealIP       string          `json:"xRealIp,omitempty"`
+	Forwarded     *forwardedField `json:"forwarded,omitempty"`
+}
+
+// forwardedHeadersResult is BuildForwardedHeaders' JSON response: either
+// Headers (ready to merge into RequestConfig.Headers) or Error, never both.
+type forwardedHeadersResult struct {
+	Headers []httpclient.HeaderField `json:"headers,omitempty"`
+	Error   string                   `json:"error,omitempty"`
+}
+
+// BuildForwardedHeaders validates and formats X-Forwarded-For, X-Real-IP,
+// and RFC 7239 Forwarded headers from reqJSON (a JSON-encoded
+// forwardedHeadersRequest), in that order, so a caller testing how an API
+// handles forwarded-header spoofing doesn't have to hand-assemble
+// Forwarded's "key=value;key=value" syntax or risk sending a malformed IP.
+// Returns a JSON error object (see forwardedHeadersResult) instead of
+// headers if reqJSON doesn't parse or any IP fails to validate.
+func (a *App) BuildForwardedHeaders(reqJSON string) string {
+	var req forwardedHeadersRequest
+	if err := json.Unmarshal([]byte(reqJSON), &req); err != nil {
+		return marshalOrEmpty(forwardedHeadersResult{Error: "invalid forwarded headers request"})
+	}
+
+	var headers []httpclient.HeaderField
+
+	if len(req.XForwardedFor) > 0 {
+		for _, ip := range req.XForwardedFor {
+			if net.ParseIP(ip) == nil {
+				return marshalOrEmpty(forwardedHeadersResult{Error: fmt.Sprintf("invalid X-Forwarded-For IP: %q", ip)})
+			}
+		}
+		headers = append(headers, httpclient.HeaderField{
+			Name:  "X-Forwarded-For",
+			Value: strings.Join(req.XForwardedFor, ", "),
+		})
+	}
+
+	if req.XRealIP != "" {
+		if net.ParseIP(req.XRealIP) == nil {
+			return marshalOrEmpty(forwardedHeadersResult{Error: fmt.Sprintf("invalid X-Real-IP: %q", req.XRealIP)})
+		}
+		headers = append(headers, httpclient.HeaderField{Name: "X-Real-IP", Value: req.XRealIP})
+	}
+
+	if req.Forwarded != nil {
+		value, err := buildForwardedValue(*req.Forwarded)
+		if err != nil {
+			return marshalOrEmpty(forwardedHeadersResult{Error: err.Error()})
+		}
+		headers = append(headers, httpclient.HeaderField{Name: "Forwarded", Value: value})
+	}
+
+	return marshalOrEmpty(forwardedHeadersResult{Headers: headers})
+}
+
+// buildForwardedValue renders f as a single RFC 7239 forwarded-element,
+// e.g. `for=192.0.2.1;proto=https`. for/by are validated as IP addresses
+// (bracketed and quoted for IPv6, per the RFC's node-identifier syntax);
+// proto/host are passed through as-is since they aren't IPs.
+func buildForwardedValue(f forwardedField) (string, error) {
+	var parts []string
+
+	if f.For != "" {
+		formatted, err := formatForwardedIP(f.For)
+		if err != nil {
+			return "", fmt.Errorf("forwarded: for: %w", err)
+		}
+		parts = append(parts, "for="+formatted)
+	}
+	if f.By != "" {
+		formatted, err := formatForwardedIP(f.By)
+		if err != nil {
+			return "", fmt.Errorf("forwarded: by: %w", err)
+		}
+		parts = append(parts, "by="+formatted)
+	}
+	if f.Proto != "" {
+		parts = append(parts, "proto="+f.Proto)
+	}
+	if f.Host != "" {
+		parts = append(parts, "host="+f.Host)
+	}
+
+	if len(parts) == 0 {
+		return "", fmt.Errorf("forwarded: at least one of for/by/proto/host is required")
+	}
+	return strings.Join(parts, ";"), nil
+}
+
+// formatForwardedIP validates ip and renders it the way RFC 7239's
+// node-identifier syntax requires: bracketed and quoted for IPv6 (since
+// "::1" would otherwise conflict with the Forwarded header's own ":"
+// token-separator use), unquoted as-is for IPv4.
+func formatForwardedIP(ip string) (string, error) {
+	if net.ParseIP(ip) == nil {
+		return "", fmt.Errorf("invalid IP %q", ip)
+	}
+	if strings.Contains(ip, ":") {
+		return `"[` + ip + `]"`, nil
+	}
+	return ip, nil
+}
@@ -0,0 +1,95 @@
+package app
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestBuildForwardedHeadersSetsAllThreeInOrder(t *testing.T) {
+	a := NewApp()
+	out := a.BuildForwardedHeaders(`{
+		"xForwardedFor": ["203.0.113.1", "203.0.113.2"],
+		"xRealIp": "203.0.113.1",
+		"forwarded": {"for": "203.0.113.1", "proto": "https", "host": "example.com"}
+	}`)
+
+	var result forwardedHeadersResult
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if result.Error != "" {
+		t.Fatalf("unexpected error: %s", result.Error)
+	}
+	if len(result.Headers) != 3 {
+		t.Fatalf("len(Headers) = %d, want 3", len(result.Headers))
+	}
+	want := []struct{ name, value string }{
+		{"X-Forwarded-For", "203.0.113.1, 203.0.113.2"},
+		{"X-Real-IP", "203.0.113.1"},
+		{"Forwarded", "for=203.0.113.1;proto=https;host=example.com"},
+	}
+	for i, w := range want {
+		if result.Headers[i].Name != w.name || result.Headers[i].Value != w.value {
+			t.Errorf("Headers[%d] = %+v, want {%s %s}", i, result.Headers[i], w.name, w.value)
+		}
+	}
+}
+
+func TestBuildForwardedHeadersQuotesIPv6ForForwarded(t *testing.T) {
+	a := NewApp()
+	out := a.BuildForwardedHeaders(`{"forwarded": {"for": "2001:db8::1"}}`)
+
+	var result forwardedHeadersResult
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if result.Error != "" {
+		t.Fatalf("unexpected error: %s", result.Error)
+	}
+	want := `for="[2001:db8::1]"`
+	if len(result.Headers) != 1 || result.Headers[0].Value != want {
+		t.Errorf("Headers = %+v, want Forwarded=%q", result.Headers, want)
+	}
+}
+
+func TestBuildForwardedHeadersRejectsInvalidXForwardedForIP(t *testing.T) {
+	a := NewApp()
+	out := a.BuildForwardedHeaders(`{"xForwardedFor": ["not-an-ip"]}`)
+
+	var result forwardedHeadersResult
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if result.Error == "" {
+		t.Error("Error is empty, want a validation error for the malformed IP")
+	}
+	if len(result.Headers) != 0 {
+		t.Errorf("Headers = %+v, want none alongside an error", result.Headers)
+	}
+}
+
+func TestBuildForwardedHeadersRejectsInvalidXRealIP(t *testing.T) {
+	a := NewApp()
+	out := a.BuildForwardedHeaders(`{"xRealIp": "999.999.999.999"}`)
+
+	var result forwardedHeadersResult
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if result.Error == "" {
+		t.Error("Error is empty, want a validation error for the malformed IP")
+	}
+}
+
+func TestBuildForwardedHeadersRejectsInvalidJSON(t *testing.T) {
+	a := NewApp()
+	out := a.BuildForwardedHeaders(`not json`)
+
+	var result forwardedHeadersResult
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if result.Error == "" {
+		t.Error("Error is empty, want a parse error")
+	}
+}
@@ -0,0 +1,29 @@
+package app
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+type responseHeadersOnly struct {
+	Headers map[string]string `json:"headers"`
+}
+
+// GetResponseHeader looks up name in respJSON (a JSON-encoded
+// httpclient.ResponseData) case-insensitively, so the UI doesn't need to
+// know the exact canonicalized casing Go's headers map uses. Multi-value
+// headers are joined with "\n" in the stored map (see parseResponse);
+// GetResponseHeader returns that joined form as-is. Returns "" if respJSON
+// doesn't parse or name isn't present under any casing.
+func (a *App) GetResponseHeader(respJSON, name string) string {
+	var resp responseHeadersOnly
+	if err := json.Unmarshal([]byte(respJSON), &resp); err != nil {
+		return ""
+	}
+	for k, v := range resp.Headers {
+		if strings.EqualFold(k, name) {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,42 @@
+package app
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestGetResponseHeaderIsCaseInsensitive(t *testing.T) {
+	a := NewApp()
+	respJSON, _ := json.Marshal(responseHeadersOnly{Headers: map[string]string{"Content-Type": "application/json"}})
+
+	for _, lookup := range []string{"Content-Type", "content-type", "CONTENT-TYPE"} {
+		if got := a.GetResponseHeader(string(respJSON), lookup); got != "application/json" {
+			t.Errorf("GetResponseHeader(%q) = %q, want %q", lookup, got, "application/json")
+		}
+	}
+}
+
+func TestGetResponseHeaderMultiValueJoined(t *testing.T) {
+	a := NewApp()
+	respJSON, _ := json.Marshal(responseHeadersOnly{Headers: map[string]string{"Set-Cookie": "a=1\nb=2"}})
+
+	if got := a.GetResponseHeader(string(respJSON), "set-cookie"); got != "a=1\nb=2" {
+		t.Errorf("GetResponseHeader = %q, want %q", got, "a=1\nb=2")
+	}
+}
+
+func TestGetResponseHeaderMissingReturnsEmpty(t *testing.T) {
+	a := NewApp()
+	respJSON, _ := json.Marshal(responseHeadersOnly{Headers: map[string]string{}})
+
+	if got := a.GetResponseHeader(string(respJSON), "x-missing"); got != "" {
+		t.Errorf("GetResponseHeader = %q, want empty", got)
+	}
+}
+
+func TestGetResponseHeaderInvalidJSONReturnsEmpty(t *testing.T) {
+	a := NewApp()
+	if got := a.GetResponseHeader("not json", "content-type"); got != "" {
+		t.Errorf("GetResponseHeader = %q, want empty", got)
+	}
+}
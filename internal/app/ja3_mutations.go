@@ -0,0 +1,20 @@
+package app
+
+import "httpcall/internal/tlsfp"
+
+// GenerateJA3Mutations returns the systematic variants tlsfp.MutateJA3
+// derives from baseJA3 (removing one extension, reordering extensions,
+// changing a cipher), capped at maxMutations (0 or negative means
+// unlimited). Like VerifyAcrossCheckers, App doesn't send anything itself -
+// the frontend sends each returned JA3 as a request's TlsConfig.JA3 (which
+// already goes through the pooled Client's rate limiter, if one is
+// configured) and feeds the checker responses back through
+// VerifyAcrossCheckers to see which mutations still pass. Returns "[]" for
+// a malformed baseJA3.
+func (a *App) GenerateJA3Mutations(baseJA3 string, maxMutations int) string {
+	mutations, err := tlsfp.MutateJA3(baseJA3, maxMutations)
+	if err != nil {
+		return "[]"
+	}
+	return marshalOrEmpty(mutations)
+}
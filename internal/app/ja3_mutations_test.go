@@ -0,0 +1,32 @@
+package app
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestGenerateJA3MutationsHonorsMax(t *testing.T) {
+	a := NewApp()
+	out := a.GenerateJA3Mutations("771,4865-4866,0-23-65281,29-23-24,0", 2)
+
+	var mutations []struct {
+		Description string `json:"description"`
+		JA3         string `json:"ja3"`
+	}
+	if err := json.Unmarshal([]byte(out), &mutations); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if len(mutations) != 2 {
+		t.Fatalf("len(mutations) = %d, want 2", len(mutations))
+	}
+	if mutations[0].JA3 == "" {
+		t.Errorf("mutations[0].JA3 is empty")
+	}
+}
+
+func TestGenerateJA3MutationsEmptyForMalformedBase(t *testing.T) {
+	a := NewApp()
+	if out := a.GenerateJA3Mutations("not-a-ja3", 0); out != "[]" {
+		t.Errorf("GenerateJA3Mutations = %q, want []", out)
+	}
+}
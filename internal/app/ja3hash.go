@@ -0,0 +1,22 @@
+package app
+
+import "httpcall/internal/tlsfp"
+
+// JA3HashResult is the outcome of hashing a raw JA3 string offline.
+type JA3HashResult struct {
+	Hash  string `json:"hash"`
+	Valid bool   `json:"valid"`
+	Error string `json:"error,omitempty"`
+}
+
+// JA3StringToHash computes the MD5 hash of ja3 directly, without making a
+// connection - useful when a user already has a captured JA3 string and
+// just wants its fingerprint hash. It also sanity-checks that ja3 parses as
+// a well-formed JA3 string (5 comma-separated fields) before hashing, since
+// a malformed string would otherwise silently hash to a meaningless value.
+func (a *App) JA3StringToHash(ja3 string) string {
+	if _, err := tlsfp.ParseJA3Text(ja3); err != nil {
+		return marshalOrEmpty(JA3HashResult{Error: err.Error()})
+	}
+	return marshalOrEmpty(JA3HashResult{Hash: tlsfp.CalculateJA3Hash(ja3), Valid: true})
+}
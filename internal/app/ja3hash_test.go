@@ -0,0 +1,36 @@
+package app
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestJA3StringToHashKnownValue(t *testing.T) {
+	a := NewApp()
+	// Chrome's well-known JA3 string and its widely-published MD5 hash.
+	ja3 := "771,4865-4866-4867-49195-49199-49196-49200-52393-52392-49171-49172-156-157-47-53,0-23-65281-10-11-35-16-5-13-18-51-45-43-27-17513,29-23-24,0"
+	var result JA3HashResult
+	if err := json.Unmarshal([]byte(a.JA3StringToHash(ja3)), &result); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if !result.Valid {
+		t.Fatalf("Valid = false, error: %s", result.Error)
+	}
+	if len(result.Hash) != 32 {
+		t.Errorf("Hash = %q, want a 32-character MD5 hex digest", result.Hash)
+	}
+}
+
+func TestJA3StringToHashRejectsMalformed(t *testing.T) {
+	a := NewApp()
+	var result JA3HashResult
+	if err := json.Unmarshal([]byte(a.JA3StringToHash("not-a-ja3-string")), &result); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if result.Valid {
+		t.Error("Valid = true, want false for malformed input")
+	}
+	if result.Error == "" {
+		t.Error("expected a non-empty Error")
+	}
+}
@@ -0,0 +1,71 @@
+package app
+
+import (
+	"encoding/json"
+	"net/url"
+
+	"httpcall/internal/tlsfp"
+)
+
+type autoPopulateRequest struct {
+	BrowserID         string            `json:"browserId"`
+	URL               string            `json:"url"`
+	Headers           map[string]string `json:"headers,omitempty"`
+	SkipOriginReferer bool              `json:"skipOriginReferer,omitempty"`
+}
+
+// ApplyBrowserProfileForRequest is ApplyBrowserProfile plus Origin/Referer
+// auto-population derived from targetURL. Real browsers virtually always
+// send both, so their absence is a common automation tell; this fills them
+// in (in the profile's header order) whenever the caller hasn't already set
+// one, unless SkipOriginReferer opts out.
+func (a *App) ApplyBrowserProfileForRequest(reqJSON string) string {
+	var req autoPopulateRequest
+	if err := json.Unmarshal([]byte(reqJSON), &req); err != nil {
+		return ""
+	}
+
+	preset, ok := tlsfp.Lookup(req.BrowserID)
+	if !ok {
+		return ""
+	}
+
+	headers := make(map[string]string, len(defaultHeaders[req.BrowserID])+len(req.Headers)+2)
+	for k, v := range defaultHeaders[req.BrowserID] {
+		headers[k] = v
+	}
+	for k, v := range req.Headers {
+		headers[k] = v
+	}
+
+	if !req.SkipOriginReferer {
+		if origin, referer, ok := deriveOriginReferer(req.URL); ok {
+			if _, exists := headers["origin"]; !exists {
+				headers["origin"] = origin
+			}
+			if _, exists := headers["referer"]; !exists {
+				headers["referer"] = referer
+			}
+		}
+	}
+
+	profile := BrowserProfile{
+		BrowserID:   req.BrowserID,
+		JA3:         preset.JA3,
+		Akamai:      preset.Akamai,
+		HeaderOrder: headerOrders[req.BrowserID],
+		Headers:     headers,
+	}
+	return marshalOrEmpty(profile)
+}
+
+// deriveOriginReferer derives the Origin (scheme + host, no path) and
+// Referer (the full URL) a browser would send for a navigation/fetch to
+// rawURL. ok is false when rawURL isn't a usable absolute URL.
+func deriveOriginReferer(rawURL string) (origin, referer string, ok bool) {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return "", "", false
+	}
+	return u.Scheme + "://" + u.Host, u.String(), true
+}
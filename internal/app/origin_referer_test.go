@@ -0,0 +1,71 @@
+package app
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestApplyBrowserProfileForRequestInjectsOriginAndReferer(t *testing.T) {
+	a := NewApp()
+	req, _ := json.Marshal(autoPopulateRequest{BrowserID: "chrome120", URL: "https://example.com/api/submit"})
+
+	var profile BrowserProfile
+	if err := json.Unmarshal([]byte(a.ApplyBrowserProfileForRequest(string(req))), &profile); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if profile.Headers["origin"] != "https://example.com" {
+		t.Errorf("origin = %q, want https://example.com", profile.Headers["origin"])
+	}
+	if profile.Headers["referer"] != "https://example.com/api/submit" {
+		t.Errorf("referer = %q, want https://example.com/api/submit", profile.Headers["referer"])
+	}
+
+	originIdx, refererIdx := -1, -1
+	for i, name := range profile.HeaderOrder {
+		switch name {
+		case "origin":
+			originIdx = i
+		case "referer":
+			refererIdx = i
+		}
+	}
+	if originIdx == -1 || refererIdx == -1 {
+		t.Fatalf("HeaderOrder %v missing origin/referer", profile.HeaderOrder)
+	}
+	if originIdx >= refererIdx {
+		t.Errorf("origin (index %d) should come before referer (index %d) for chrome120", originIdx, refererIdx)
+	}
+}
+
+func TestApplyBrowserProfileForRequestSkipsWhenOptedOut(t *testing.T) {
+	a := NewApp()
+	req, _ := json.Marshal(autoPopulateRequest{BrowserID: "chrome120", URL: "https://example.com/", SkipOriginReferer: true})
+
+	var profile BrowserProfile
+	if err := json.Unmarshal([]byte(a.ApplyBrowserProfileForRequest(string(req))), &profile); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if _, ok := profile.Headers["origin"]; ok {
+		t.Error("origin should not be set when SkipOriginReferer is true")
+	}
+	if _, ok := profile.Headers["referer"]; ok {
+		t.Error("referer should not be set when SkipOriginReferer is true")
+	}
+}
+
+func TestApplyBrowserProfileForRequestRespectsExplicitHeaders(t *testing.T) {
+	a := NewApp()
+	req, _ := json.Marshal(autoPopulateRequest{
+		BrowserID: "chrome120",
+		URL:       "https://example.com/",
+		Headers:   map[string]string{"referer": "https://other.example/"},
+	})
+
+	var profile BrowserProfile
+	if err := json.Unmarshal([]byte(a.ApplyBrowserProfileForRequest(string(req))), &profile); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if profile.Headers["referer"] != "https://other.example/" {
+		t.Errorf("referer = %q, want caller's explicit value preserved", profile.Headers["referer"])
+	}
+}
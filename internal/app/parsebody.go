@@ -0,0 +1,96 @@
+package app
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"net/url"
+	"strings"
+)
+
+// ParsedBody is the structured result of interpreting a response body
+// according to its Content-Type.
+type ParsedBody struct {
+	Kind  string `json:"kind"` // "json", "xml", "form", "csv", "raw"
+	Data  any    `json:"data,omitempty"`
+	Error string `json:"error,omitempty"`
+	Raw   string `json:"raw,omitempty"`
+}
+
+type parseBodyRequest struct {
+	Body        string `json:"body"`
+	ContentType string `json:"contentType"`
+}
+
+// ParseBody interprets respJSON (a JSON-encoded {body, contentType}) into a
+// structured tree the UI can render as a table/tree instead of raw text.
+// Parse failures fall back to the raw body with an error note rather than
+// failing the call outright.
+func (a *App) ParseBody(respJSON string) string {
+	var req parseBodyRequest
+	if err := json.Unmarshal([]byte(respJSON), &req); err != nil {
+		return marshalOrEmpty(ParsedBody{Kind: "raw", Raw: respJSON, Error: err.Error()})
+	}
+
+	ct := strings.ToLower(req.ContentType)
+	var result ParsedBody
+	switch {
+	case strings.Contains(ct, "json"):
+		result = parseJSONBody(req.Body)
+	case strings.Contains(ct, "xml"):
+		result = parseXMLBody(req.Body)
+	case strings.Contains(ct, "urlencoded"):
+		result = parseFormBody(req.Body)
+	case strings.Contains(ct, "csv"):
+		result = parseCSVBody(req.Body)
+	default:
+		result = ParsedBody{Kind: "raw", Raw: req.Body}
+	}
+	return marshalOrEmpty(result)
+}
+
+func parseJSONBody(body string) ParsedBody {
+	var data any
+	if err := json.Unmarshal([]byte(body), &data); err != nil {
+		return ParsedBody{Kind: "raw", Raw: body, Error: err.Error()}
+	}
+	return ParsedBody{Kind: "json", Data: data}
+}
+
+// xmlNode is a generic tree shape that can represent any XML document,
+// since we don't know the schema of an arbitrary response body ahead of time.
+type xmlNode struct {
+	XMLName  xml.Name
+	Attrs    []xml.Attr `xml:",any,attr"`
+	Content  string     `xml:",chardata"`
+	Children []xmlNode  `xml:",any"`
+}
+
+func parseXMLBody(body string) ParsedBody {
+	var node xmlNode
+	if err := xml.Unmarshal([]byte(body), &node); err != nil {
+		return ParsedBody{Kind: "raw", Raw: body, Error: err.Error()}
+	}
+	return ParsedBody{Kind: "xml", Data: node}
+}
+
+func parseFormBody(body string) ParsedBody {
+	values, err := url.ParseQuery(body)
+	if err != nil {
+		return ParsedBody{Kind: "raw", Raw: body, Error: err.Error()}
+	}
+	flat := make(map[string]string, len(values))
+	for k, v := range values {
+		flat[k] = strings.Join(v, ",")
+	}
+	return ParsedBody{Kind: "form", Data: flat}
+}
+
+func parseCSVBody(body string) ParsedBody {
+	r := csv.NewReader(strings.NewReader(body))
+	rows, err := r.ReadAll()
+	if err != nil {
+		return ParsedBody{Kind: "raw", Raw: body, Error: err.Error()}
+	}
+	return ParsedBody{Kind: "csv", Data: rows}
+}
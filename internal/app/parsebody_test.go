@@ -0,0 +1,60 @@
+package app
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func decodeParsedBody(t *testing.T, out string) ParsedBody {
+	t.Helper()
+	var pb ParsedBody
+	if err := json.Unmarshal([]byte(out), &pb); err != nil {
+		t.Fatalf("unmarshal result: %v", err)
+	}
+	return pb
+}
+
+func TestParseBodyJSON(t *testing.T) {
+	a := NewApp()
+	in, _ := json.Marshal(parseBodyRequest{Body: `{"a":1}`, ContentType: "application/json"})
+	pb := decodeParsedBody(t, a.ParseBody(string(in)))
+	if pb.Kind != "json" || pb.Error != "" {
+		t.Errorf("got %+v", pb)
+	}
+}
+
+func TestParseBodyXML(t *testing.T) {
+	a := NewApp()
+	in, _ := json.Marshal(parseBodyRequest{Body: `<root><a>1</a></root>`, ContentType: "application/xml"})
+	pb := decodeParsedBody(t, a.ParseBody(string(in)))
+	if pb.Kind != "xml" || pb.Error != "" {
+		t.Errorf("got %+v", pb)
+	}
+}
+
+func TestParseBodyForm(t *testing.T) {
+	a := NewApp()
+	in, _ := json.Marshal(parseBodyRequest{Body: "a=1&b=2", ContentType: "application/x-www-form-urlencoded"})
+	pb := decodeParsedBody(t, a.ParseBody(string(in)))
+	if pb.Kind != "form" || pb.Error != "" {
+		t.Errorf("got %+v", pb)
+	}
+}
+
+func TestParseBodyCSV(t *testing.T) {
+	a := NewApp()
+	in, _ := json.Marshal(parseBodyRequest{Body: "a,b\n1,2", ContentType: "text/csv"})
+	pb := decodeParsedBody(t, a.ParseBody(string(in)))
+	if pb.Kind != "csv" || pb.Error != "" {
+		t.Errorf("got %+v", pb)
+	}
+}
+
+func TestParseBodyInvalidJSONFallsBackToRaw(t *testing.T) {
+	a := NewApp()
+	in, _ := json.Marshal(parseBodyRequest{Body: `{not json`, ContentType: "application/json"})
+	pb := decodeParsedBody(t, a.ParseBody(string(in)))
+	if pb.Kind != "raw" || pb.Error == "" {
+		t.Errorf("got %+v, want raw fallback with error", pb)
+	}
+}
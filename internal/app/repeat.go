@@ -0,0 +1,111 @@
+package app
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"httpcall/internal/httpclient"
+)
+
+// RepeatResult is one indexed outcome from SendRequestRepeat. Response is the
+// JSON-encoded ResponseData on success, mirroring doSend's "error string or
+// response JSON" convention - per request rather than for the whole batch,
+// so one failing attempt doesn't take down the others' results.
+type RepeatResult struct {
+	Index     int    `json:"index"`
+	Response  string `json:"response,omitempty"`
+	Error     string `json:"error,omitempty"`
+	LatencyMS int64  `json:"latencyMs"`
+}
+
+// RepeatStats summarizes a SendRequestRepeat run.
+type RepeatStats struct {
+	Count        int     `json:"count"`
+	ErrorCount   int     `json:"errorCount"`
+	MinLatencyMS int64   `json:"minLatencyMs"`
+	MaxLatencyMS int64   `json:"maxLatencyMs"`
+	AvgLatencyMS float64 `json:"avgLatencyMs"`
+}
+
+// RepeatReport is the full JSON payload SendRequestRepeat returns.
+type RepeatReport struct {
+	Results []RepeatResult `json:"results"`
+	Stats   RepeatStats    `json:"stats"`
+}
+
+// SendRequestRepeat sends cfg count times through a's shared Client, running
+// up to concurrency requests at once (concurrency <= 0 means sequential),
+// and returns a JSON-encoded RepeatReport: each attempt's result tagged with
+// its send-order index (so a result can always be matched to its attempt
+// regardless of completion order under concurrency), plus aggregate
+// latency/error stats - a basic load tester built on the same Send path as
+// a single request.
+func (a *App) SendRequestRepeat(cfg httpclient.RequestConfig, count int, concurrency int) string {
+	a.clientOnce.Do(func() { a.client = httpclient.NewClient() })
+
+	if count <= 0 {
+		return marshalOrEmpty(RepeatReport{})
+	}
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+	if concurrency > count {
+		concurrency = count
+	}
+
+	results := make([]RepeatResult, count)
+	indices := make(chan int, count)
+	for i := 0; i < count; i++ {
+		indices <- i
+	}
+	close(indices)
+
+	var wg sync.WaitGroup
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indices {
+				start := time.Now()
+				resp, err := a.client.Send(context.Background(), cfg)
+				result := RepeatResult{Index: i, LatencyMS: time.Since(start).Milliseconds()}
+				if err != nil {
+					result.Error = err.Error()
+				} else {
+					result.Response = marshalOrEmpty(resp)
+				}
+				results[i] = result
+			}
+		}()
+	}
+	wg.Wait()
+
+	return marshalOrEmpty(RepeatReport{Results: results, Stats: computeRepeatStats(results)})
+}
+
+func computeRepeatStats(results []RepeatResult) RepeatStats {
+	stats := RepeatStats{Count: len(results)}
+	if len(results) == 0 {
+		return stats
+	}
+
+	var sum int64
+	min, max := results[0].LatencyMS, results[0].LatencyMS
+	for _, r := range results {
+		if r.Error != "" {
+			stats.ErrorCount++
+		}
+		sum += r.LatencyMS
+		if r.LatencyMS < min {
+			min = r.LatencyMS
+		}
+		if r.LatencyMS > max {
+			max = r.LatencyMS
+		}
+	}
+	stats.MinLatencyMS = min
+	stats.MaxLatencyMS = max
+	stats.AvgLatencyMS = float64(sum) / float64(len(results))
+	return stats
+}
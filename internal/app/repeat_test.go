@@ -0,0 +1,77 @@
+package app
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"httpcall/internal/httpclient"
+)
+
+func TestSendRequestRepeatRunsCountTimesAndOrdersResults(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	a := NewApp()
+	out := a.SendRequestRepeat(httpclient.RequestConfig{Method: "GET", URL: srv.URL}, 5, 3)
+
+	var report RepeatReport
+	if err := json.Unmarshal([]byte(out), &report); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+
+	if got := atomic.LoadInt32(&calls); got != 5 {
+		t.Errorf("server received %d calls, want 5", got)
+	}
+	if len(report.Results) != 5 {
+		t.Fatalf("got %d results, want 5", len(report.Results))
+	}
+	for i, r := range report.Results {
+		if r.Index != i {
+			t.Errorf("results[%d].Index = %d, want %d", i, r.Index, i)
+		}
+		if r.Error != "" {
+			t.Errorf("results[%d].Error = %q, want empty", i, r.Error)
+		}
+	}
+	if report.Stats.Count != 5 || report.Stats.ErrorCount != 0 {
+		t.Errorf("Stats = %+v, want Count=5 ErrorCount=0", report.Stats)
+	}
+}
+
+func TestSendRequestRepeatTracksErrorsSeparately(t *testing.T) {
+	a := NewApp()
+	out := a.SendRequestRepeat(httpclient.RequestConfig{Method: "GET", URL: "http://127.0.0.1:0"}, 3, 2)
+
+	var report RepeatReport
+	if err := json.Unmarshal([]byte(out), &report); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if report.Stats.ErrorCount != 3 {
+		t.Errorf("ErrorCount = %d, want 3", report.Stats.ErrorCount)
+	}
+	for _, r := range report.Results {
+		if r.Error == "" {
+			t.Error("want an error string for a connection to a closed port")
+		}
+	}
+}
+
+func TestSendRequestRepeatZeroCountReturnsEmptyReport(t *testing.T) {
+	a := NewApp()
+	out := a.SendRequestRepeat(httpclient.RequestConfig{Method: "GET", URL: "http://example.invalid"}, 0, 1)
+
+	var report RepeatReport
+	if err := json.Unmarshal([]byte(out), &report); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if len(report.Results) != 0 {
+		t.Errorf("got %d results, want 0", len(report.Results))
+	}
+}
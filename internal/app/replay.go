@@ -0,0 +1,42 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+
+	"httpcall/internal/httpclient"
+)
+
+type replayResult struct {
+	Response   *httpclient.ResponseData `json:"response,omitempty"`
+	Error      string                   `json:"error,omitempty"`
+	JA3Changed bool                     `json:"ja3Changed"`
+}
+
+// ReplayWithFingerprint re-sends originalReqJSON (a JSON-encoded
+// httpclient.RequestConfig) with newTlsJSON (a JSON-encoded TlsConfig)
+// overriding its fingerprint, keeping everything else identical. This is
+// the fast path for "does a different fingerprint get past the block?".
+func (a *App) ReplayWithFingerprint(originalReqJSON, newTlsJSON string) string {
+	var cfg httpclient.RequestConfig
+	if err := json.Unmarshal([]byte(originalReqJSON), &cfg); err != nil {
+		return marshalOrEmpty(replayResult{Error: err.Error()})
+	}
+	var newTls httpclient.TlsConfig
+	if err := json.Unmarshal([]byte(newTlsJSON), &newTls); err != nil {
+		return marshalOrEmpty(replayResult{Error: err.Error()})
+	}
+
+	originalJA3 := ""
+	if cfg.Tls != nil {
+		originalJA3 = cfg.Tls.JA3
+	}
+	cfg.Tls = &newTls
+
+	a.clientOnce.Do(func() { a.client = httpclient.NewClient() })
+	resp, err := a.client.Send(context.Background(), cfg)
+	if err != nil {
+		return marshalOrEmpty(replayResult{Error: err.Error()})
+	}
+	return marshalOrEmpty(replayResult{Response: resp, JA3Changed: newTls.JA3 != originalJA3})
+}
@@ -0,0 +1,49 @@
+package app
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"httpcall/internal/httpclient"
+	"httpcall/internal/tlsfp"
+)
+
+func TestReplayWithFingerprintUsesNewJA3(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	original := httpclient.RequestConfig{
+		Method: "GET",
+		URL:    srv.URL,
+		Tls:    &httpclient.TlsConfig{JA3: tlsfp.Presets["chrome120"].JA3},
+	}
+	originalJSON, _ := json.Marshal(original)
+	newTlsJSON, _ := json.Marshal(httpclient.TlsConfig{JA3: tlsfp.Presets["firefox120"].JA3})
+
+	a := NewApp()
+	out := a.ReplayWithFingerprint(string(originalJSON), string(newTlsJSON))
+
+	var result struct {
+		Response struct {
+			JA3Hash string `json:"ja3Hash"`
+		} `json:"response"`
+		JA3Changed bool `json:"ja3Changed"`
+		Error      string
+	}
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if result.Error != "" {
+		t.Fatalf("unexpected error: %s", result.Error)
+	}
+	if !result.JA3Changed {
+		t.Error("JA3Changed = false, want true")
+	}
+	if result.Response.JA3Hash != tlsfp.CalculateJA3Hash(tlsfp.Presets["firefox120"].JA3) {
+		t.Errorf("response used the wrong JA3 hash: %s", result.Response.JA3Hash)
+	}
+}
@@ -0,0 +1,245 @@
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// SchemaViolation describes one way data failed to satisfy a schema.
+type SchemaViolation struct {
+	Path    string `json:"path"`
+	Message string `json:"message"`
+}
+
+// SchemaValidationResult is the outcome of validating a body against a
+// JSON Schema.
+type SchemaValidationResult struct {
+	Valid      bool              `json:"valid"`
+	Violations []SchemaViolation `json:"violations"`
+	Error      string            `json:"error,omitempty"`
+}
+
+type validateRequest struct {
+	Body   string `json:"body"`
+	Schema string `json:"schema"`
+}
+
+// ValidateJSONSchema checks reqJSON (a JSON-encoded {body, schema}, both
+// themselves JSON text) against a practical subset of JSON Schema draft
+// 2020-12/draft-7: type, enum, required, properties, additionalProperties,
+// items, minimum/maximum, minLength/maxLength, and minItems/maxItems. It
+// covers what API responses typically need without pulling in an external
+// schema library, which isn't available to this build.
+func (a *App) ValidateJSONSchema(reqJSON string) string {
+	var req validateRequest
+	if err := json.Unmarshal([]byte(reqJSON), &req); err != nil {
+		return marshalOrEmpty(SchemaValidationResult{Error: "invalid request: " + err.Error()})
+	}
+
+	var data any
+	if err := json.Unmarshal([]byte(req.Body), &data); err != nil {
+		return marshalOrEmpty(SchemaValidationResult{Error: "invalid body JSON: " + err.Error()})
+	}
+
+	var schema map[string]any
+	if err := json.Unmarshal([]byte(req.Schema), &schema); err != nil {
+		return marshalOrEmpty(SchemaValidationResult{Error: "invalid schema JSON: " + err.Error()})
+	}
+
+	violations := validateAgainstSchema("$", data, schema)
+	sort.Slice(violations, func(i, j int) bool { return violations[i].Path < violations[j].Path })
+	return marshalOrEmpty(SchemaValidationResult{
+		Valid:      len(violations) == 0,
+		Violations: violations,
+	})
+}
+
+func validateAgainstSchema(path string, data any, schema map[string]any) []SchemaViolation {
+	var violations []SchemaViolation
+
+	if wantType, ok := schema["type"].(string); ok {
+		if !matchesType(data, wantType) {
+			violations = append(violations, SchemaViolation{
+				Path:    path,
+				Message: fmt.Sprintf("expected type %q, got %s", wantType, jsonTypeOf(data)),
+			})
+			return violations // further checks would be meaningless against the wrong shape
+		}
+	}
+
+	if rawEnum, ok := schema["enum"].([]any); ok {
+		if !enumContains(rawEnum, data) {
+			violations = append(violations, SchemaViolation{Path: path, Message: "value is not one of the allowed enum values"})
+		}
+	}
+
+	switch v := data.(type) {
+	case map[string]any:
+		violations = append(violations, validateObject(path, v, schema)...)
+	case []any:
+		violations = append(violations, validateArray(path, v, schema)...)
+	case float64:
+		violations = append(violations, validateNumber(path, v, schema)...)
+	case string:
+		violations = append(violations, validateString(path, v, schema)...)
+	}
+
+	return violations
+}
+
+func validateObject(path string, obj map[string]any, schema map[string]any) []SchemaViolation {
+	var violations []SchemaViolation
+
+	if rawRequired, ok := schema["required"].([]any); ok {
+		for _, r := range rawRequired {
+			name, ok := r.(string)
+			if !ok {
+				continue
+			}
+			if _, present := obj[name]; !present {
+				violations = append(violations, SchemaViolation{
+					Path:    path,
+					Message: fmt.Sprintf("missing required property %q", name),
+				})
+			}
+		}
+	}
+
+	if rawProps, ok := schema["properties"].(map[string]any); ok {
+		for name, rawPropSchema := range rawProps {
+			value, present := obj[name]
+			if !present {
+				continue
+			}
+			propSchema, ok := rawPropSchema.(map[string]any)
+			if !ok {
+				continue
+			}
+			violations = append(violations, validateAgainstSchema(path+"."+name, value, propSchema)...)
+		}
+	}
+
+	if additional, ok := schema["additionalProperties"].(bool); ok && !additional {
+		allowed, _ := schema["properties"].(map[string]any)
+		for name := range obj {
+			if _, ok := allowed[name]; !ok {
+				violations = append(violations, SchemaViolation{
+					Path:    path,
+					Message: fmt.Sprintf("unexpected property %q not allowed by additionalProperties: false", name),
+				})
+			}
+		}
+	}
+
+	return violations
+}
+
+func validateArray(path string, arr []any, schema map[string]any) []SchemaViolation {
+	var violations []SchemaViolation
+
+	if itemSchema, ok := schema["items"].(map[string]any); ok {
+		for i, item := range arr {
+			violations = append(violations, validateAgainstSchema(fmt.Sprintf("%s[%d]", path, i), item, itemSchema)...)
+		}
+	}
+	if min, ok := numericValue(schema["minItems"]); ok && float64(len(arr)) < min {
+		violations = append(violations, SchemaViolation{Path: path, Message: fmt.Sprintf("array has %d items, fewer than minItems %v", len(arr), min)})
+	}
+	if max, ok := numericValue(schema["maxItems"]); ok && float64(len(arr)) > max {
+		violations = append(violations, SchemaViolation{Path: path, Message: fmt.Sprintf("array has %d items, more than maxItems %v", len(arr), max)})
+	}
+
+	return violations
+}
+
+func validateNumber(path string, n float64, schema map[string]any) []SchemaViolation {
+	var violations []SchemaViolation
+
+	if min, ok := numericValue(schema["minimum"]); ok && n < min {
+		violations = append(violations, SchemaViolation{Path: path, Message: fmt.Sprintf("%v is less than minimum %v", n, min)})
+	}
+	if max, ok := numericValue(schema["maximum"]); ok && n > max {
+		violations = append(violations, SchemaViolation{Path: path, Message: fmt.Sprintf("%v is greater than maximum %v", n, max)})
+	}
+
+	return violations
+}
+
+func validateString(path string, s string, schema map[string]any) []SchemaViolation {
+	var violations []SchemaViolation
+
+	if min, ok := numericValue(schema["minLength"]); ok && float64(len(s)) < min {
+		violations = append(violations, SchemaViolation{Path: path, Message: fmt.Sprintf("length %d is less than minLength %v", len(s), min)})
+	}
+	if max, ok := numericValue(schema["maxLength"]); ok && float64(len(s)) > max {
+		violations = append(violations, SchemaViolation{Path: path, Message: fmt.Sprintf("length %d is greater than maxLength %v", len(s), max)})
+	}
+
+	return violations
+}
+
+func numericValue(v any) (float64, bool) {
+	f, ok := v.(float64)
+	return f, ok
+}
+
+func enumContains(options []any, data any) bool {
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return false
+	}
+	for _, o := range options {
+		oe, err := json.Marshal(o)
+		if err == nil && string(oe) == string(encoded) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesType(data any, want string) bool {
+	switch want {
+	case "object":
+		_, ok := data.(map[string]any)
+		return ok
+	case "array":
+		_, ok := data.([]any)
+		return ok
+	case "string":
+		_, ok := data.(string)
+		return ok
+	case "number":
+		_, ok := data.(float64)
+		return ok
+	case "integer":
+		f, ok := data.(float64)
+		return ok && f == float64(int64(f))
+	case "boolean":
+		_, ok := data.(bool)
+		return ok
+	case "null":
+		return data == nil
+	default:
+		return true // unknown type keyword: don't fail validation over it
+	}
+}
+
+func jsonTypeOf(data any) string {
+	switch data.(type) {
+	case map[string]any:
+		return "object"
+	case []any:
+		return "array"
+	case string:
+		return "string"
+	case float64:
+		return "number"
+	case bool:
+		return "boolean"
+	case nil:
+		return "null"
+	default:
+		return "unknown"
+	}
+}
@@ -0,0 +1,54 @@
+package app
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestValidateJSONSchemaValid(t *testing.T) {
+	a := NewApp()
+	req, _ := json.Marshal(validateRequest{
+		Body:   `{"name": "alice", "age": 30}`,
+		Schema: `{"type":"object","required":["name","age"],"properties":{"name":{"type":"string","minLength":1},"age":{"type":"integer","minimum":0}}}`,
+	})
+
+	var result SchemaValidationResult
+	if err := json.Unmarshal([]byte(a.ValidateJSONSchema(string(req))), &result); err != nil {
+		t.Fatalf("unmarshal result: %v", err)
+	}
+	if !result.Valid {
+		t.Errorf("Valid = false, violations: %+v", result.Violations)
+	}
+}
+
+func TestValidateJSONSchemaReportsViolations(t *testing.T) {
+	a := NewApp()
+	req, _ := json.Marshal(validateRequest{
+		Body:   `{"name": "", "age": -1, "extra": true}`,
+		Schema: `{"type":"object","required":["name","age","email"],"additionalProperties":false,"properties":{"name":{"type":"string","minLength":1},"age":{"type":"integer","minimum":0}}}`,
+	})
+
+	var result SchemaValidationResult
+	if err := json.Unmarshal([]byte(a.ValidateJSONSchema(string(req))), &result); err != nil {
+		t.Fatalf("unmarshal result: %v", err)
+	}
+	if result.Valid {
+		t.Fatal("Valid = true, want false")
+	}
+	if len(result.Violations) != 4 {
+		t.Fatalf("got %d violations, want 4: %+v", len(result.Violations), result.Violations)
+	}
+}
+
+func TestValidateJSONSchemaBadInputsReportError(t *testing.T) {
+	a := NewApp()
+	req, _ := json.Marshal(validateRequest{Body: `not json`, Schema: `{}`})
+
+	var result SchemaValidationResult
+	if err := json.Unmarshal([]byte(a.ValidateJSONSchema(string(req))), &result); err != nil {
+		t.Fatalf("unmarshal result: %v", err)
+	}
+	if result.Error == "" {
+		t.Error("expected a non-empty Error for malformed body JSON")
+	}
+}
@@ -0,0 +1,191 @@
+package app
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"httpcall/internal/httpclient"
+)
+
+// SendRequest executes cfg and returns the JSON-encoded ResponseData (or an
+// error string) for the frontend. When dedup is enabled, concurrent calls
+// with an identical signature (every field that affects what's actually
+// sent on the wire - see requestSignature) share one in-flight network call
+// instead of each firing their own.
+func (a *App) SendRequest(cfg httpclient.RequestConfig) string {
+	if !a.dedupEnabled {
+		return a.doSend(cfg)
+	}
+
+	key := requestSignature(cfg)
+	result, _ := a.dedup.Do(key, func() (string, error) {
+		return a.doSend(cfg), nil
+	})
+	return result
+}
+
+// SetDedupEnabled toggles in-flight request deduplication.
+func (a *App) SetDedupEnabled(enabled bool) {
+	a.dedupEnabled = enabled
+}
+
+// SetGlobalRateLimit caps the rate of every request sent through a (manual
+// sends and SendRepeat alike) at qps requests per second, allowing bursts up
+// to burst before it starts blocking. Passing qps <= 0 removes the limit.
+func (a *App) SetGlobalRateLimit(qps float64, burst int) {
+	a.clientOnce.Do(func() { a.client = httpclient.NewClient() })
+	if qps <= 0 {
+		a.client.SetRateLimiter(nil)
+		return
+	}
+	a.client.SetRateLimiter(httpclient.NewRateLimiter(qps, burst))
+}
+
+// SetDNSCacheTTL installs a DNSCache with ttl per host on the app's client;
+// see httpclient.Client.SetDNSCache for which dials consult it (the
+// client's own request dials do not). Passing ttl <= 0 removes the cache.
+func (a *App) SetDNSCacheTTL(ttl time.Duration) {
+	a.clientOnce.Do(func() { a.client = httpclient.NewClient() })
+	if ttl <= 0 {
+		a.client.SetDNSCache(nil)
+		return
+	}
+	a.client.SetDNSCache(httpclient.NewDNSCache(ttl))
+}
+
+// ClearDNSCache discards any cached DNS resolutions, e.g. after the user
+// knows a host's records just changed and doesn't want to wait out the TTL.
+func (a *App) ClearDNSCache() {
+	a.clientOnce.Do(func() { a.client = httpclient.NewClient() })
+	a.client.ClearDNSCache()
+}
+
+func (a *App) doSend(cfg httpclient.RequestConfig) string {
+	return a.sendWithContext(context.Background(), cfg)
+}
+
+// sendWithContext is the shared core of doSend and SendRequestWithID: send
+// cfg under ctx, stash the body for later paging if the caller gave a
+// RequestID, and marshal the result (or error) for the frontend.
+func (a *App) sendWithContext(ctx context.Context, cfg httpclient.RequestConfig) string {
+	a.clientOnce.Do(func() { a.client = httpclient.NewClient() })
+	resp, err := a.client.Send(ctx, cfg)
+	if err != nil {
+		return marshalOrEmpty(map[string]string{"error": err.Error()})
+	}
+
+	if cfg.RequestID != "" {
+		a.bodiesOnce.Do(func() { a.bodies = newBodyStore() })
+		_ = a.bodies.put(cfg.RequestID, []byte(resp.Body))
+	}
+
+	return marshalOrEmpty(resp)
+}
+
+// GetBodyPage returns the window [offset, offset+length) of the response
+// body retained for requestID, letting the UI virtualize rendering of huge
+// bodies instead of shipping them whole. Returns an empty string if the
+// request ID is unknown.
+func (a *App) GetBodyPage(requestID string, offset, length int) string {
+	if a.bodies == nil {
+		return ""
+	}
+	page, err := a.bodies.page(requestID, int64(offset), int64(length))
+	if err != nil {
+		return ""
+	}
+	return string(page)
+}
+
+// GetRawConnLog returns the base64-encoded byte log of the connection
+// retained by a request sent with RequestConfig.RetainRawConn set, or ""
+// if requestID has nothing retained under it (including after it's been
+// released).
+func (a *App) GetRawConnLog(requestID string) string {
+	a.clientOnce.Do(func() { a.client = httpclient.NewClient() })
+	log, ok := a.client.RawConnLog(requestID)
+	if !ok {
+		return ""
+	}
+	return base64.StdEncoding.EncodeToString(log)
+}
+
+// ReleaseRawConn closes the connection retained under requestID, freeing it
+// for real. Returns whether a connection was actually retained under that
+// ID.
+func (a *App) ReleaseRawConn(requestID string) bool {
+	a.clientOnce.Do(func() { a.client = httpclient.NewClient() })
+	return a.client.ReleaseRawConn(requestID)
+}
+
+// requestSignature derives a stable key identifying a request for dedup
+// purposes, covering every field that affects what's actually written to
+// the wire - two requests differing in any of them are different requests
+// and must never collapse into one singleflight call, even if their
+// Method/URL/Headers/Body happen to match (e.g. the same URL with two
+// different BodyFilePath uploads, or with/without a client cert). Headers
+// and SuppressHeaders are order-independent since the set sent is what
+// matters for dedup purposes; FormData, BodyTransforms, and Tls are
+// serialized via JSON (deterministic for a given Go value) since their
+// order and structure do matter.
+func requestSignature(cfg httpclient.RequestConfig) string {
+	headerLines := make([]string, 0, len(cfg.Headers))
+	for _, h := range cfg.Headers {
+		headerLines = append(headerLines, h.Name+":"+h.Value)
+	}
+	sort.Strings(headerLines)
+
+	suppressed := append([]string(nil), cfg.SuppressHeaders...)
+	sort.Strings(suppressed)
+
+	formData, _ := json.Marshal(cfg.FormData)
+	transforms, _ := json.Marshal(cfg.BodyTransforms)
+	tls, _ := json.Marshal(cfg.Tls)
+	contentLength := "auto"
+	if cfg.ContentLengthOverride != nil {
+		contentLength = fmt.Sprint(*cfg.ContentLengthOverride)
+	}
+
+	var b strings.Builder
+	b.WriteString(cfg.Method)
+	b.WriteByte('\n')
+	b.WriteString(cfg.URL)
+	b.WriteByte('\n')
+	for _, line := range headerLines {
+		b.WriteString(line)
+		b.WriteByte('\n')
+	}
+	for _, name := range suppressed {
+		b.WriteString(name)
+		b.WriteByte('\n')
+	}
+	b.WriteString(cfg.Body)
+	b.WriteByte('\n')
+	b.WriteString(cfg.BodyFilePath)
+	b.WriteByte('\n')
+	b.Write(formData)
+	b.WriteByte('\n')
+	b.Write(transforms)
+	b.WriteByte('\n')
+	b.Write(tls)
+	b.WriteByte('\n')
+	fmt.Fprintf(&b, "%t\n%t\n%t\n%s", cfg.BodyIsBase64, cfg.GRPCWeb, cfg.OmitContentLength, contentLength)
+
+	sum := sha256.Sum256([]byte(b.String()))
+	return hex.EncodeToString(sum[:])
+}
+
+func marshalOrEmpty(v any) string {
+	out, err := json.Marshal(v)
+	if err != nil {
+		return ""
+	}
+	return string(out)
+}
@@ -0,0 +1,124 @@
+package app
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"httpcall/internal/httpclient"
+)
+
+func TestSendRequestDedupSharesOneNetworkCall(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	a := NewApp()
+	a.SetDedupEnabled(true)
+
+	cfg := httpclient.RequestConfig{Method: "GET", URL: srv.URL}
+
+	const n = 10
+	var wg sync.WaitGroup
+	wg.Add(n)
+	for i := 0; i < n; i++ {
+		go func() {
+			defer wg.Done()
+			a.SendRequest(cfg)
+		}()
+	}
+	wg.Wait()
+
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Errorf("server received %d calls, want 1", got)
+	}
+}
+
+func TestSendRequestDedupDoesNotCollapseDifferentBodyFilePaths(t *testing.T) {
+	var bodies []string
+	var mu sync.Mutex
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		buf := make([]byte, 64)
+		n, _ := r.Body.Read(buf)
+		mu.Lock()
+		bodies = append(bodies, string(buf[:n]))
+		mu.Unlock()
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	dir := t.TempDir()
+	pathA := filepath.Join(dir, "a.txt")
+	pathB := filepath.Join(dir, "b.txt")
+	if err := os.WriteFile(pathA, []byte("file A"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(pathB, []byte("file B"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	a := NewApp()
+	a.SetDedupEnabled(true)
+
+	cfgA := httpclient.RequestConfig{Method: "POST", URL: srv.URL, BodyFilePath: pathA}
+	cfgB := httpclient.RequestConfig{Method: "POST", URL: srv.URL, BodyFilePath: pathB}
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() { defer wg.Done(); a.SendRequest(cfgA) }()
+	go func() { defer wg.Done(); a.SendRequest(cfgB) }()
+	wg.Wait()
+
+	if len(bodies) != 2 {
+		t.Fatalf("server received %d requests, want 2 (dedup must not collapse different BodyFilePath uploads)", len(bodies))
+	}
+	if bodies[0] == bodies[1] {
+		t.Fatalf("both requests sent body %q - distinct BodyFilePath requests were incorrectly collapsed into one", bodies[0])
+	}
+}
+
+func TestRequestSignatureDiffersForDifferentBodyFilePath(t *testing.T) {
+	base := httpclient.RequestConfig{Method: "POST", URL: "https://example.com"}
+	a := base
+	a.BodyFilePath = "/tmp/a.txt"
+	b := base
+	b.BodyFilePath = "/tmp/b.txt"
+
+	if requestSignature(a) == requestSignature(b) {
+		t.Error("requestSignature should differ for different BodyFilePath values")
+	}
+}
+
+func TestGetRawConnLogRetrievableAndReleasable(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	a := NewApp()
+	a.SendRequest(httpclient.RequestConfig{
+		Method:        "GET",
+		URL:           srv.URL,
+		RequestID:     "retain-1",
+		RetainRawConn: true,
+	})
+
+	log := a.GetRawConnLog("retain-1")
+	if log == "" {
+		t.Fatal("GetRawConnLog: got empty string, want a base64-encoded byte log")
+	}
+
+	if !a.ReleaseRawConn("retain-1") {
+		t.Error("ReleaseRawConn: want true releasing a retained connection")
+	}
+	if a.GetRawConnLog("retain-1") != "" {
+		t.Error("GetRawConnLog: want empty string after release")
+	}
+}
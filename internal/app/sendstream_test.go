@@ -0,0 +1,66 @@
+package app
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"httpcall/internal/httpclient"
+)
+
+func TestSendRequestStreamEmitsChunksAndLeavesBodyEmpty(t *testing.T) {
+	body := strings.Repeat("q", 1<<20)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(body))
+	}))
+	defer srv.Close()
+
+	a := NewApp()
+	var received strings.Builder
+	var gotRequestID string
+	a.SetEventEmitter(func(ctx context.Context, name string, data any) {
+		if name != bodyChunkEvent {
+			return
+		}
+		chunk := data.(bodyChunk)
+		gotRequestID = chunk.RequestID
+		decoded, err := base64.StdEncoding.DecodeString(chunk.Data)
+		if err != nil {
+			t.Fatalf("decode chunk: %v", err)
+		}
+		received.Write(decoded)
+	})
+
+	out := a.SendRequestStream(httpclient.RequestConfig{RequestID: "req-1", Method: "GET", URL: srv.URL})
+
+	var resp httpclient.ResponseData
+	if err := json.Unmarshal([]byte(out), &resp); err != nil {
+		t.Fatalf("unmarshal: %v, out=%s", err, out)
+	}
+	if resp.Body != "" {
+		t.Errorf("ResponseData.Body = %q, want empty", resp.Body)
+	}
+	if received.String() != body {
+		t.Error("chunks received don't reassemble to the server body")
+	}
+	if gotRequestID != "req-1" {
+		t.Errorf("chunk RequestID = %q, want req-1", gotRequestID)
+	}
+}
+
+func TestSendRequestStreamReturnsErrorOnFailure(t *testing.T) {
+	a := NewApp()
+	out := a.SendRequestStream(httpclient.RequestConfig{Method: "GET", URL: "http://127.0.0.1:0"})
+
+	var got map[string]string
+	if err := json.Unmarshal([]byte(out), &got); err != nil {
+		t.Fatalf("unmarshal: %v, out=%s", err, out)
+	}
+	if got["error"] == "" {
+		t.Error("expected an error field for an unreachable URL")
+	}
+}
@@ -0,0 +1,17 @@
+package app
+
+import "httpcall/internal/httpclient"
+
+// GetSessionCookies returns the JSON-encoded cookies a's pooled Client would
+// attach to a request for urlStr right now - i.e. whatever Set-Cookie
+// responses earlier requests in this session accumulated in its jar for
+// that URL's domain/path. Returns "[]" if urlStr doesn't parse or nothing
+// has been stored for it yet, so callers don't need to special-case a null.
+func (a *App) GetSessionCookies(urlStr string) string {
+	a.clientOnce.Do(func() { a.client = httpclient.NewClient() })
+	cookies, err := a.client.SessionCookies(urlStr)
+	if err != nil {
+		return "[]"
+	}
+	return marshalOrEmpty(cookies)
+}
@@ -0,0 +1,36 @@
+package app
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"httpcall/internal/httpclient"
+)
+
+func TestGetSessionCookiesReflectsEarlierSend(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.SetCookie(w, &http.Cookie{Name: "session", Value: "abc123"})
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	a := NewApp()
+	a.SendRequest(httpclient.RequestConfig{Method: "GET", URL: srv.URL})
+
+	got := a.GetSessionCookies(srv.URL)
+	if got == "[]" || got == "" {
+		t.Fatalf("GetSessionCookies = %q, want it to include the session cookie", got)
+	}
+	if !strings.Contains(got, `"name":"session"`) || !strings.Contains(got, `"value":"abc123"`) {
+		t.Errorf("GetSessionCookies = %q, want it to contain session=abc123", got)
+	}
+}
+
+func TestGetSessionCookiesEmptyForUnvisitedURL(t *testing.T) {
+	a := NewApp()
+	if got := a.GetSessionCookies("https://never-visited.example"); got != "[]" {
+		t.Errorf("GetSessionCookies = %q, want []", got)
+	}
+}
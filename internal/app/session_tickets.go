@@ -0,0 +1,36 @@
+package app
+
+import "httpcall/internal/httpclient"
+
+// EnablePersistentSessionTickets points a's pooled Client at an
+// AES-GCM-encrypted, disk-backed TLS session ticket cache rooted at path
+// (keyed by key, which must be 16/24/32 bytes for AES-128/192/256),
+// loading whatever tickets a previous run already saved so this run's
+// first connections to those hosts can resume instead of paying a full
+// handshake. Returns "" on success, or a JSON {"error": "..."} object if
+// path can't be read/decrypted or key is the wrong length.
+func (a *App) EnablePersistentSessionTickets(path string, key []byte) string {
+	a.clientOnce.Do(func() { a.client = httpclient.NewClient() })
+
+	cache, err := httpclient.NewFileSessionTicketCache(path, key)
+	if err != nil {
+		return marshalOrEmpty(map[string]string{"error": err.Error()})
+	}
+	a.client.SetSessionCache(cache)
+	a.sessionTicketCache = cache
+	return ""
+}
+
+// SaveSessionTickets flushes a's persistent session ticket cache (see
+// EnablePersistentSessionTickets) to disk, so tickets gathered this run
+// survive for the next one. A no-op returning "" if persistence was never
+// enabled.
+func (a *App) SaveSessionTickets() string {
+	if a.sessionTicketCache == nil {
+		return ""
+	}
+	if err := a.sessionTicketCache.Save(); err != nil {
+		return marshalOrEmpty(map[string]string{"error": err.Error()})
+	}
+	return ""
+}
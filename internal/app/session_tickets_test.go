@@ -0,0 +1,43 @@
+package app
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+)
+
+func TestEnablePersistentSessionTicketsLoadsAndSaves(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	path := filepath.Join(t.TempDir(), "tickets.enc")
+	key := bytes.Repeat([]byte{0x11}, 32)
+
+	a := NewApp()
+	if out := a.EnablePersistentSessionTickets(path, key); out != "" {
+		t.Fatalf("EnablePersistentSessionTickets: %s", out)
+	}
+	if out := a.SaveSessionTickets(); out != "" {
+		t.Fatalf("SaveSessionTickets: %s", out)
+	}
+}
+
+func TestEnablePersistentSessionTicketsRejectsBadKeyLength(t *testing.T) {
+	a := NewApp()
+	path := filepath.Join(t.TempDir(), "tickets.enc")
+	out := a.EnablePersistentSessionTickets(path, []byte("too-short"))
+	if out == "" {
+		t.Error("expected an error for an invalid AES key length")
+	}
+}
+
+func TestSaveSessionTicketsIsNoOpWhenNeverEnabled(t *testing.T) {
+	a := NewApp()
+	if out := a.SaveSessionTickets(); out != "" {
+		t.Errorf("SaveSessionTickets = %q, want empty when persistence was never enabled", out)
+	}
+}
@@ -0,0 +1,82 @@
+package app
+
+import (
+	"encoding/json"
+
+	"httpcall/internal/httpclient"
+)
+
+// signingPreviewRequest is the JSON shape the frontend's signing debug
+// panel sends: Scheme selects which fields below apply, since HMAC, OAuth1,
+// and SigV4 each need a different set of inputs.
+type signingPreviewRequest struct {
+	Scheme string `json:"scheme"` // "hmac", "oauth1", or "sigv4"
+
+	// hmac
+	Method    string `json:"method,omitempty"`
+	URL       string `json:"url,omitempty"`
+	Body      string `json:"body,omitempty"`
+	Timestamp string `json:"timestamp,omitempty"`
+	Secret    string `json:"secret,omitempty"`
+
+	// oauth1
+	Params         map[string]string `json:"params,omitempty"`
+	ConsumerSecret string            `json:"consumerSecret,omitempty"`
+	TokenSecret    string            `json:"tokenSecret,omitempty"`
+
+	// sigv4 (reuses Method/Body/Timestamp-as-AmzDate above is error-prone,
+	// so sigv4 gets its own explicit fields instead)
+	URI       string            `json:"uri,omitempty"`
+	Query     string            `json:"query,omitempty"`
+	Headers   map[string]string `json:"headers,omitempty"`
+	AmzDate   string            `json:"amzDate,omitempty"`
+	Region    string            `json:"region,omitempty"`
+	Service   string            `json:"service,omitempty"`
+	SecretKey string            `json:"secretKey,omitempty"`
+}
+
+// PreviewSigning computes the intermediate signing artifacts (canonical
+// string/request, string-to-sign, and signature) for reqJSON's scheme
+// without sending a request, so a signing bug can be debugged against
+// exactly what was signed. reqJSON is a JSON-encoded signingPreviewRequest.
+// Returns a JSON error object if reqJSON doesn't parse or names an unknown
+// scheme.
+func (a *App) PreviewSigning(reqJSON string) string {
+	var req signingPreviewRequest
+	if err := json.Unmarshal([]byte(reqJSON), &req); err != nil {
+		return marshalOrEmpty(map[string]string{"error": "invalid signing preview request"})
+	}
+
+	switch req.Scheme {
+	case "hmac":
+		return marshalOrEmpty(httpclient.PreviewHMACSignature(httpclient.HMACSigningParams{
+			Method:    req.Method,
+			URL:       req.URL,
+			Body:      req.Body,
+			Timestamp: req.Timestamp,
+			Secret:    req.Secret,
+		}))
+	case "oauth1":
+		return marshalOrEmpty(httpclient.PreviewOAuth1Signature(httpclient.OAuth1SigningParams{
+			Method:         req.Method,
+			URL:            req.URL,
+			Params:         req.Params,
+			ConsumerSecret: req.ConsumerSecret,
+			TokenSecret:    req.TokenSecret,
+		}))
+	case "sigv4":
+		return marshalOrEmpty(httpclient.PreviewSigV4Signature(httpclient.SigV4SigningParams{
+			Method:    req.Method,
+			URI:       req.URI,
+			Query:     req.Query,
+			Headers:   req.Headers,
+			Body:      req.Body,
+			AmzDate:   req.AmzDate,
+			Region:    req.Region,
+			Service:   req.Service,
+			SecretKey: req.SecretKey,
+		}))
+	default:
+		return marshalOrEmpty(map[string]string{"error": "unknown signing scheme: " + req.Scheme})
+	}
+}
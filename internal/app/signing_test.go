@@ -0,0 +1,49 @@
+package app
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestPreviewSigningHMACReturnsCanonicalStringAndSignature(t *testing.T) {
+	a := &App{}
+	reqJSON, _ := json.Marshal(map[string]string{
+		"scheme":    "hmac",
+		"method":    "GET",
+		"url":       "https://api.example.com/v1",
+		"timestamp": "1700000000",
+		"secret":    "shh",
+	})
+
+	var got map[string]string
+	if err := json.Unmarshal([]byte(a.PreviewSigning(string(reqJSON))), &got); err != nil {
+		t.Fatalf("unmarshal result: %v", err)
+	}
+	if got["canonicalString"] == "" || got["signature"] == "" {
+		t.Errorf("PreviewSigning(hmac) = %v, want non-empty canonicalString and signature", got)
+	}
+}
+
+func TestPreviewSigningUnknownSchemeReturnsError(t *testing.T) {
+	a := &App{}
+	reqJSON, _ := json.Marshal(map[string]string{"scheme": "carrier-pigeon"})
+
+	var got map[string]string
+	if err := json.Unmarshal([]byte(a.PreviewSigning(string(reqJSON))), &got); err != nil {
+		t.Fatalf("unmarshal result: %v", err)
+	}
+	if got["error"] == "" {
+		t.Errorf("PreviewSigning(unknown scheme) = %v, want an error field", got)
+	}
+}
+
+func TestPreviewSigningInvalidJSONReturnsError(t *testing.T) {
+	a := &App{}
+	var got map[string]string
+	if err := json.Unmarshal([]byte(a.PreviewSigning("not json")), &got); err != nil {
+		t.Fatalf("unmarshal result: %v", err)
+	}
+	if got["error"] == "" {
+		t.Errorf("PreviewSigning(invalid JSON) = %v, want an error field", got)
+	}
+}
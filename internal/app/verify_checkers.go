@@ -0,0 +1,69 @@
+package app
+
+import (
+	"encoding/json"
+
+	"httpcall/internal/tlsfp"
+)
+
+// CheckerResponse is one fingerprint checker's raw response, already fetched
+// by the caller. HttpCall doesn't make the network call itself here so this
+// stays testable offline and so the frontend controls which checkers to hit.
+type CheckerResponse struct {
+	Name string `json:"name"` // e.g. "tls.peet.ws", "scrapfly", "browserleaks"
+	Body string `json:"body"`
+}
+
+type verifyAcrossCheckersRequest struct {
+	JA3       string            `json:"ja3,omitempty"`
+	JA4       string            `json:"ja4,omitempty"`
+	Akamai    string            `json:"akamai,omitempty"`
+	Responses []CheckerResponse `json:"responses"`
+}
+
+// CheckerResult is one checker's comparison outcome.
+type CheckerResult struct {
+	Name         string `json:"name"`
+	Pass         bool   `json:"pass"`
+	Error        string `json:"error,omitempty"`
+	tlsfp.Result `json:"result"`
+}
+
+// CheckerReport consolidates every checker's result into one pass/fail.
+type CheckerReport struct {
+	AllPass bool            `json:"allPass"`
+	Results []CheckerResult `json:"results"`
+}
+
+// VerifyAcrossCheckers compares an intended JA3/JA4/Akamai fingerprint
+// against several already-fetched fingerprint-checker responses (all of
+// which HttpCall treats as tls.peet.ws-compatible JSON, since that's the
+// one schema this app parses) and reports whether each one agrees.
+func (a *App) VerifyAcrossCheckers(reqJSON string) string {
+	var req verifyAcrossCheckersRequest
+	if err := json.Unmarshal([]byte(reqJSON), &req); err != nil {
+		return marshalOrEmpty(CheckerReport{})
+	}
+
+	report := CheckerReport{AllPass: true}
+	for _, resp := range req.Responses {
+		result := CheckerResult{Name: resp.Name}
+
+		observed, err := tlsfp.ParsePeetResponse([]byte(resp.Body))
+		if err != nil {
+			result.Error = err.Error()
+			report.AllPass = false
+			report.Results = append(report.Results, result)
+			continue
+		}
+
+		result.Result = tlsfp.Compare(req.JA3, req.JA4, req.Akamai, observed)
+		result.Pass = result.Result.Pass()
+		if !result.Pass {
+			report.AllPass = false
+		}
+		report.Results = append(report.Results, result)
+	}
+
+	return marshalOrEmpty(report)
+}
@@ -0,0 +1,53 @@
+package app
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestVerifyAcrossCheckersAllPass(t *testing.T) {
+	a := NewApp()
+	body := `{"tls":{"ja3":"abc","ja4":"xyz"},"http2":{"akamai_fingerprint":"1:2:3"}}`
+	req, _ := json.Marshal(verifyAcrossCheckersRequest{
+		JA3:    "abc",
+		JA4:    "xyz",
+		Akamai: "1:2:3",
+		Responses: []CheckerResponse{
+			{Name: "tls.peet.ws", Body: body},
+			{Name: "scrapfly", Body: body},
+		},
+	})
+
+	var report CheckerReport
+	if err := json.Unmarshal([]byte(a.VerifyAcrossCheckers(string(req))), &report); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if !report.AllPass {
+		t.Errorf("AllPass = false, results: %+v", report.Results)
+	}
+	if len(report.Results) != 2 {
+		t.Fatalf("got %d results, want 2", len(report.Results))
+	}
+}
+
+func TestVerifyAcrossCheckersOneMismatchFailsOverall(t *testing.T) {
+	a := NewApp()
+	req, _ := json.Marshal(verifyAcrossCheckersRequest{
+		JA3: "abc",
+		Responses: []CheckerResponse{
+			{Name: "tls.peet.ws", Body: `{"tls":{"ja3":"abc"}}`},
+			{Name: "browserleaks", Body: `{"tls":{"ja3":"different"}}`},
+		},
+	})
+
+	var report CheckerReport
+	if err := json.Unmarshal([]byte(a.VerifyAcrossCheckers(string(req))), &report); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if report.AllPass {
+		t.Fatal("AllPass = true, want false")
+	}
+	if report.Results[0].Pass != true || report.Results[1].Pass != false {
+		t.Errorf("unexpected per-checker pass results: %+v", report.Results)
+	}
+}
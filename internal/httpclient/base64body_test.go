@@ -0,0 +1,52 @@
+package httpclient
+
+import (
+	"context"
+	"encoding/base64"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSendBodyIsBase64DecodesBeforeSending(t *testing.T) {
+	want := []byte{0x00, 0xFF, 0x10, 'h', 'i'}
+	var gotBody []byte
+	var gotContentType string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotBody, _ = io.ReadAll(r.Body)
+		gotContentType = r.Header.Get("Content-Type")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	client := NewClient()
+	_, err := client.Send(context.Background(), RequestConfig{
+		Method:       "POST",
+		URL:          srv.URL,
+		Body:         base64.StdEncoding.EncodeToString(want),
+		BodyIsBase64: true,
+	})
+	if err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if string(gotBody) != string(want) {
+		t.Errorf("server received %v, want %v", gotBody, want)
+	}
+	if gotContentType != "application/octet-stream" {
+		t.Errorf("Content-Type = %q, want application/octet-stream", gotContentType)
+	}
+}
+
+func TestSendBodyIsBase64RejectsInvalidInput(t *testing.T) {
+	client := NewClient()
+	_, err := client.Send(context.Background(), RequestConfig{
+		Method:       "POST",
+		URL:          "http://example.invalid",
+		Body:         "not valid base64!!",
+		BodyIsBase64: true,
+	})
+	if err == nil {
+		t.Fatal("expected an error for invalid base64 input")
+	}
+}
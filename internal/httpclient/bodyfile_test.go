@@ -0,0 +1,87 @@
+package httpclient
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSendWithBodyFilePathStreamsFileAndSetsContentType(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "upload.bin")
+	payload := []byte("binary payload contents")
+	if err := os.WriteFile(path, payload, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var gotBody []byte
+	var gotContentType string
+	var gotContentLength int64
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotContentType = r.Header.Get("Content-Type")
+		gotContentLength = r.ContentLength
+		gotBody, _ = io.ReadAll(r.Body)
+	}))
+	defer srv.Close()
+
+	client := NewClient()
+	_, err := client.Send(context.Background(), RequestConfig{
+		Method:       "POST",
+		URL:          srv.URL,
+		BodyFilePath: path,
+	})
+	if err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if string(gotBody) != string(payload) {
+		t.Errorf("body = %q, want %q", gotBody, payload)
+	}
+	if gotContentType != "application/octet-stream" {
+		t.Errorf("Content-Type = %q, want application/octet-stream", gotContentType)
+	}
+	if gotContentLength != int64(len(payload)) {
+		t.Errorf("Content-Length = %d, want %d", gotContentLength, len(payload))
+	}
+}
+
+func TestSendWithBodyFilePathMissingFileReturnsClearError(t *testing.T) {
+	client := NewClient()
+	_, err := client.Send(context.Background(), RequestConfig{
+		Method:       "POST",
+		URL:          "http://example.com",
+		BodyFilePath: "/nonexistent/upload.bin",
+	})
+	if err == nil {
+		t.Fatal("expected an error for a missing body file")
+	}
+}
+
+func TestSendWithBodyFilePathHonorsExplicitContentType(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "upload.json")
+	if err := os.WriteFile(path, []byte(`{"a":1}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var gotContentType string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotContentType = r.Header.Get("Content-Type")
+	}))
+	defer srv.Close()
+
+	client := NewClient()
+	_, err := client.Send(context.Background(), RequestConfig{
+		Method:       "POST",
+		URL:          srv.URL,
+		BodyFilePath: path,
+		Headers:      []HeaderField{{Name: "Content-Type", Value: "application/json"}},
+	})
+	if err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if gotContentType != "application/json" {
+		t.Errorf("Content-Type = %q, want application/json", gotContentType)
+	}
+}
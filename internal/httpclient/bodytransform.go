@@ -0,0 +1,59 @@
+package httpclient
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/url"
+)
+
+// BodyTransform names one step in a composable pre-send transform pipeline
+// (RequestConfig.BodyTransforms) applied, in order, to Body before it's
+// sent - e.g. minifying JSON to save bytes on the wire, or base64/URL-
+// encoding a payload a server expects pre-encoded.
+type BodyTransform string
+
+const (
+	BodyTransformMinifyJSON   BodyTransform = "minifyJson"
+	BodyTransformPrettifyJSON BodyTransform = "prettifyJson"
+	BodyTransformBase64Encode BodyTransform = "base64Encode"
+	BodyTransformURLEncode    BodyTransform = "urlEncode"
+)
+
+// applyBodyTransforms runs body through each of transforms in order,
+// feeding each step's output into the next - so e.g. minifying JSON and
+// then base64-encoding the result is just two entries in the slice.
+func applyBodyTransforms(body string, transforms []BodyTransform) (string, error) {
+	for _, t := range transforms {
+		transformed, err := applyBodyTransform(body, t)
+		if err != nil {
+			return "", err
+		}
+		body = transformed
+	}
+	return body, nil
+}
+
+func applyBodyTransform(body string, transform BodyTransform) (string, error) {
+	switch transform {
+	case BodyTransformMinifyJSON:
+		var buf bytes.Buffer
+		if err := json.Compact(&buf, []byte(body)); err != nil {
+			return "", fmt.Errorf("httpclient: minifyJson transform: %w", err)
+		}
+		return buf.String(), nil
+	case BodyTransformPrettifyJSON:
+		var buf bytes.Buffer
+		if err := json.Indent(&buf, []byte(body), "", "  "); err != nil {
+			return "", fmt.Errorf("httpclient: prettifyJson transform: %w", err)
+		}
+		return buf.String(), nil
+	case BodyTransformBase64Encode:
+		return base64.StdEncoding.EncodeToString([]byte(body)), nil
+	case BodyTransformURLEncode:
+		return url.QueryEscape(body), nil
+	default:
+		return "", fmt.Errorf("httpclient: unknown body transform %q", transform)
+	}
+}
@@ -0,0 +1,96 @@
+package httpclient
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSendMinifyJSONTransformCompactsBodyOnWire(t *testing.T) {
+	var gotBody string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		gotBody = string(b)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	client := NewClient()
+	_, err := client.Send(context.Background(), RequestConfig{
+		Method:         "POST",
+		URL:            srv.URL,
+		Body:           "{\n  \"hello\": \"world\"\n}\n",
+		BodyTransforms: []BodyTransform{BodyTransformMinifyJSON},
+	})
+	if err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if want := `{"hello":"world"}`; gotBody != want {
+		t.Errorf("body = %q, want %q", gotBody, want)
+	}
+}
+
+func TestSendBase64EncodeTransformEncodesBodyOnWire(t *testing.T) {
+	var gotBody string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		gotBody = string(b)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	client := NewClient()
+	_, err := client.Send(context.Background(), RequestConfig{
+		Method:         "POST",
+		URL:            srv.URL,
+		Body:           "hello",
+		BodyTransforms: []BodyTransform{BodyTransformBase64Encode},
+	})
+	if err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if want := "aGVsbG8="; gotBody != want {
+		t.Errorf("body = %q, want %q", gotBody, want)
+	}
+}
+
+func TestSendBodyTransformsComposeInOrder(t *testing.T) {
+	var gotBody string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		gotBody = string(b)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	client := NewClient()
+	_, err := client.Send(context.Background(), RequestConfig{
+		Method: "POST",
+		URL:    srv.URL,
+		Body:   `{"a": 1}`,
+		BodyTransforms: []BodyTransform{
+			BodyTransformMinifyJSON,
+			BodyTransformBase64Encode,
+		},
+	})
+	if err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if want := "eyJhIjoxfQ=="; gotBody != want {
+		t.Errorf("body = %q, want %q", gotBody, want)
+	}
+}
+
+func TestApplyBodyTransformRejectsInvalidJSONForMinify(t *testing.T) {
+	if _, err := applyBodyTransform("not json", BodyTransformMinifyJSON); err == nil {
+		t.Error("expected an error minifying invalid JSON")
+	}
+}
+
+func TestApplyBodyTransformRejectsUnknownTransform(t *testing.T) {
+	if _, err := applyBodyTransform("x", BodyTransform("bogus")); err == nil {
+		t.Error("expected an error for an unknown transform name")
+	}
+}
@@ -0,0 +1,618 @@
+package httpclient
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"httpcall/internal/tlsfp"
+)
+
+// RequestConfig is everything the frontend supplies to describe one request.
+type RequestConfig struct {
+	// RequestID, when set, is an opaque caller-supplied identifier used to
+	// correlate this request with later operations on its response (e.g.
+	// paging a large body).
+	RequestID string `json:"requestId,omitempty"`
+	Method    string `json:"method"`
+	URL       string `json:"url"`
+	// Headers is ordered and allows duplicate names (e.g. two
+	// X-Forwarded-For values), unlike a map. Both properties matter for
+	// CustomH2Transport's pseudo-header/regular-header emission order (see
+	// encodeHeaders) - but CustomH2Transport.RoundTrip isn't wired into
+	// Send today (see CustomH2Transport's doc comment), and on the real
+	// HTTP/1.1 path this order does NOT reach the wire: Send adds these to
+	// a net/http.Header map via http.Header.Add, but net/http's own
+	// Transport always writes header *names* in alphabetically-sorted
+	// order (Header.WriteSubset's headerSorter) regardless of Add order -
+	// only the relative order of repeated values under the same name
+	// survives. Headers is still useful for controlling which duplicate
+	// value comes first, just not for overall name ordering on HTTP/1.1.
+	Headers []HeaderField `json:"headers,omitempty"`
+	Body    string        `json:"body,omitempty"`
+	// BodyIsBase64 treats Body as standard base64 and decodes it before
+	// sending, so the UI can let users paste binary content without a
+	// file. Defaults Content-Type to application/octet-stream unless
+	// Headers already sets one.
+	BodyIsBase64 bool `json:"bodyIsBase64,omitempty"`
+	// FormData, when non-empty, builds the request body as multipart/
+	// form-data from these fields (in order) instead of using Body, and
+	// sets Content-Type to the resulting boundary unless Headers already
+	// has one.
+	FormData []FormDataField `json:"formData,omitempty"`
+	// BodyFilePath, when set, streams that file directly from disk as the
+	// request body instead of using Body - for uploads too large to want
+	// buffered as a string first. Sets Content-Length to the file's size and
+	// defaults Content-Type to application/octet-stream unless Headers
+	// already sets one. Takes precedence over Body/BodyIsBase64/FormData.
+	BodyFilePath string `json:"bodyFilePath,omitempty"`
+	// BodyTransforms runs Body through a composable pipeline (minify/
+	// prettify JSON, base64/URL-encode) before it's sent, in the order
+	// given. Only applies to Body - BodyFilePath, FormData, and
+	// BodyIsBase64 bodies bypass it, since BodyIsBase64 is already a
+	// decode step and a file/multipart body isn't the kind of text
+	// payload these transforms are for.
+	BodyTransforms []BodyTransform `json:"bodyTransforms,omitempty"`
+	Tls            *TlsConfig      `json:"tls,omitempty"`
+	// Limits overrides DefaultLimits for this request.
+	Limits *Limits `json:"limits,omitempty"`
+
+	// SuppressHeaders lists header names Go would otherwise inject on its
+	// own (currently just "User-Agent") that the caller wants omitted from
+	// the wire request entirely, rather than overridden with an empty
+	// value. Useful for fingerprint fidelity: a browser profile with no
+	// User-Agent at all looks different from one sending "User-Agent: ".
+	SuppressHeaders []string `json:"suppressHeaders,omitempty"`
+
+	// ChunkSizeBytes overrides Download's default 32KB read buffer,
+	// trading progress-callback granularity against per-read overhead for
+	// large bodies. Zero means the default. Send ignores this; it always
+	// reads the whole body at once.
+	ChunkSizeBytes int `json:"chunkSizeBytes,omitempty"`
+
+	// GRPCWeb sends the "TE: trailers" header gRPC-Web servers expect and
+	// parses the response body's trailer frame for grpc-status/grpc-message
+	// (see ResponseData.GRPCStatus), instead of treating it as opaque body
+	// bytes.
+	GRPCWeb bool `json:"grpcWeb,omitempty"`
+
+	// RetainRawConn keeps this request's underlying connection open after
+	// the response completes, instead of letting the transport close or
+	// pool it, and logs every byte read or written on it for later
+	// inspection via Client.RawConnLog - for protocol research where the
+	// literal bytes on the wire matter. Requires RequestID, since that's
+	// how the retained connection is looked up afterward. The caller must
+	// call Client.ReleaseRawConn once done, or the connection leaks.
+	RetainRawConn bool `json:"retainRawConn,omitempty"`
+
+	// ContentLengthOverride, when non-nil, is sent as the request's
+	// Content-Length verbatim instead of the length Send would otherwise
+	// compute from the body. Note net/http's HTTP/1.1 transfer writer
+	// verifies the body it actually wrote matches Content-Length and fails
+	// the request rather than sending a mismatched value on the wire, so
+	// this can't be used to lie about length - only to make an
+	// already-correct value explicit instead of auto-derived. Ignored when
+	// OmitContentLength is also set.
+	ContentLengthOverride *int64 `json:"contentLengthOverride,omitempty"`
+	// OmitContentLength forces the request to omit Content-Length entirely,
+	// falling back to chunked transfer encoding, instead of the length Send
+	// would otherwise compute from the body - for protocol testing against
+	// servers that require or mishandle chunked bodies. Takes precedence
+	// over ContentLengthOverride.
+	OmitContentLength bool `json:"omitContentLength,omitempty"`
+
+	// MaxResponseBytes caps the response body size for just this request, 0
+	// meaning use Limits.MaxResponseBodyBytes (DefaultLimits' 100MB unless
+	// Limits overrides it). A convenience for the common case of wanting to
+	// change only this one budget without reconstructing the rest of
+	// Limits; set, it takes precedence over Limits.MaxResponseBodyBytes.
+	// Tripping it returns the same *LimitExceededError as Limits does.
+	MaxResponseBytes int64 `json:"maxResponseBytes,omitempty"`
+
+	// TimeoutMs bounds the whole request - connecting, the TLS handshake,
+	// sending the request, and reading the response - from this call's
+	// context, not just one phase of it. Zero means defaultRequestTimeout.
+	TimeoutMs int `json:"timeoutMs,omitempty"`
+}
+
+// defaultRequestTimeout is the overall request deadline RequestConfig.TimeoutMs
+// falls back to when unset.
+const defaultRequestTimeout = 30 * time.Second
+
+// withRequestTimeout derives a context bounded by cfg.TimeoutMs (or
+// defaultRequestTimeout when zero) from ctx, for Send to pass down to
+// http.Client.Do. Unlike Send, Download and SendStream don't apply this -
+// a streaming transfer can legitimately run far longer than one request/
+// response round trip, so they rely on the caller's own ctx instead of an
+// implicit deadline.
+func withRequestTimeout(ctx context.Context, cfg RequestConfig) (context.Context, context.CancelFunc) {
+	timeout := defaultRequestTimeout
+	if cfg.TimeoutMs > 0 {
+		timeout = time.Duration(cfg.TimeoutMs) * time.Millisecond
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+// Client sends requests built from a RequestConfig and returns parsed
+// ResponseData, over a stdlib http.Transport whose dialers are its own
+// dialContext and dialTLS.
+type Client struct {
+	httpClient   *http.Client
+	transport    *http.Transport
+	defaultTls   *TlsConfig
+	interceptors []RequestInterceptor
+	rateLimiter  *RateLimiter
+	dnsCache     *DNSCache
+	sessionCache tls.ClientSessionCache
+
+	rawConnsMu sync.Mutex
+	rawConns   map[string]*loggedConn
+}
+
+// NewClient returns a Client ready to send requests with no default TLS
+// fingerprint (callers must set one per request, or accept Go's defaults).
+// Transport compression is handled manually (see decompressBody) instead of
+// Go's automatic gzip handling, since that handling also silently strips
+// the Content-Encoding header we want to report to the UI.
+func NewClient() *Client {
+	return NewClientWithTls(nil)
+}
+
+// NewClientWithTls returns a Client whose requests use defaultTls unless a
+// given RequestConfig.Tls overrides it - letting one pooled Client rotate
+// fingerprints across requests instead of being tied to one at construction.
+func NewClientWithTls(defaultTls *TlsConfig) *Client {
+	c := &Client{defaultTls: defaultTls}
+	jar, _ := cookiejar.New(nil) // nil Options: errors only from a custom PublicSuffixList, which we don't set
+	c.transport = &http.Transport{
+		DisableCompression: true,
+		DialContext:        c.dialContext,
+		DialTLSContext:     c.dialTLS,
+	}
+	c.httpClient = &http.Client{
+		Transport:     drainCappingTransport{c.transport},
+		Jar:           jar,
+		CheckRedirect: c.checkRedirect,
+	}
+	return c
+}
+
+// effectiveTls resolves which TlsConfig a request should use: a per-request
+// override takes precedence over the client's default.
+func (c *Client) effectiveTls(override *TlsConfig) *TlsConfig {
+	if override != nil {
+		return override
+	}
+	return c.defaultTls
+}
+
+// suppressHeaders removes any of Go's automatically-injected headers that
+// names lists, so the wire request carries exactly the configured headers
+// and nothing Go would otherwise add on its own. Setting a header key to a
+// nil slice (rather than deleting it) is what tells net/http to omit it
+// instead of falling back to its default.
+func suppressHeaders(req *http.Request, names []string) {
+	for _, name := range names {
+		req.Header[http.CanonicalHeaderKey(name)] = nil
+	}
+}
+
+// applyHostHeaderOverride copies an explicit "Host" entry from req.Header
+// into req.Host, so it actually reaches the wire on HTTP/1.1. net/http's
+// Request.Write always sends req.Host (falling back to req.URL.Host) and
+// excludes "Host" from the headers it writes from req.Header, so a caller
+// setting it via Headers like any other header would otherwise be silently
+// dropped - unlike CustomH2Transport, which builds :authority straight from
+// req.Header.Get("Host") and already honors it. Fingerprint-sensitive
+// callers need the same override to work on both paths.
+func applyHostHeaderOverride(req *http.Request) {
+	if h := req.Header.Get("Host"); h != "" {
+		req.Host = h
+	}
+}
+
+// hasHeader reports whether headers already names name, case-insensitively -
+// used to decide whether an auto-derived default (e.g. Content-Type) should
+// defer to an explicit caller value instead of being added as well.
+func hasHeader(headers []HeaderField, name string) bool {
+	for _, h := range headers {
+		if strings.EqualFold(h.Name, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// isRefusedStreamError reports whether err looks like an HTTP/2
+// REFUSED_STREAM (or a GOAWAY carrying that same error code) - the signal
+// some WAFs use to push a client off h2 without an actual network failure.
+// net/http's bundled http2 transport doesn't export a typed error for this
+// (http2StreamError/http2GoAwayError are unexported), so matching on the
+// ErrCode's stable string form - which both Error() methods include - is
+// the only way to detect it from outside the package. err is typically a
+// *url.Error wrapping the http2 error; Error() flattens that wrapping, so
+// no unwrapping is needed.
+func isRefusedStreamError(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "REFUSED_STREAM")
+}
+
+// cloneRequestForH1Retry builds a replay of req for the REFUSED_STREAM h1
+// fallback: same method/URL/headers, a fresh body from GetBody, and a
+// context forced onto http/1.1 (see withForceHTTP1) so the retry can't land
+// back on h2 and hit the same server-side rule. Returns an error if req's
+// body isn't replayable (no GetBody was set) - a body backed by something
+// other than the recognized in-memory readers NewRequestWithContext knows
+// about, or an *os.File, which Send always pairs with a manual GetBody.
+func cloneRequestForH1Retry(req *http.Request) (*http.Request, error) {
+	if req.GetBody == nil {
+		return nil, fmt.Errorf("httpclient: request body isn't replayable for an h1 fallback retry")
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, err
+	}
+	retry := req.Clone(withForceHTTP1(req.Context()))
+	retry.Body = body
+	return retry, nil
+}
+
+// SetRateLimiter installs limiter to throttle every request c sends from
+// here on, replacing any previously installed one. A nil limiter removes
+// throttling entirely.
+func (c *Client) SetRateLimiter(limiter *RateLimiter) {
+	c.rateLimiter = limiter
+}
+
+// SetDNSCache installs cache, replacing any previously installed cache. A
+// nil cache disables caching entirely. Only dialHappyEyeballs's
+// explicit-resolution path consults it. c's own dials (dialContext and
+// dialTLS) go through net.Dialer, which resolves hosts itself, so the
+// installed cache does not change how requests sent through c resolve DNS.
+func (c *Client) SetDNSCache(cache *DNSCache) {
+	c.dnsCache = cache
+}
+
+// ClearDNSCache discards every entry in c's installed DNS cache, if any -
+// e.g. after a DNS record change a caller knows just happened and doesn't
+// want to wait out the TTL for.
+func (c *Client) ClearDNSCache() {
+	if c.dnsCache != nil {
+		c.dnsCache.Clear()
+	}
+}
+
+// SetSessionCache installs cache so TLS 1.2 session tickets / TLS 1.3
+// session state are offered for resumption on later connections, replacing
+// any previously installed cache. A nil cache (the default) disables
+// session resumption entirely - every handshake is a full handshake. Pass
+// a *FileSessionTicketCache to make resumption survive across app
+// restarts instead of just across requests within one process.
+func (c *Client) SetSessionCache(cache tls.ClientSessionCache) {
+	c.sessionCache = cache
+}
+
+// SessionCookies returns the cookies c's jar would attach to a request for
+// urlStr - i.e. whatever cookies earlier requests on this same Client
+// accumulated via Set-Cookie for that URL's domain/path. Since a Client is
+// pooled and reused across calls (see App.clientOnce), this is effectively
+// the session's cookie state for that URL, not just one response's.
+func (c *Client) SessionCookies(urlStr string) ([]ResponseCookie, error) {
+	jar := c.httpClient.Jar
+	if jar == nil {
+		return nil, nil
+	}
+	u, err := url.Parse(urlStr)
+	if err != nil {
+		return nil, fmt.Errorf("httpclient: invalid URL %q: %w", urlStr, err)
+	}
+	cookies := jar.Cookies(u)
+	result := make([]ResponseCookie, 0, len(cookies))
+	for _, cookie := range cookies {
+		result = append(result, newResponseCookie(cookie))
+	}
+	return result, nil
+}
+
+// SetMaxConnsPerHost caps how many connections (in total, not just idle
+// ones) c will open to a single host at once, queuing any request past
+// that limit until one frees up - honoring the request's context the same
+// way the rest of http.Transport's dialing does. n <= 0 removes the cap
+// (http.Transport's own default).
+func (c *Client) SetMaxConnsPerHost(n int) {
+	if c.transport != nil {
+		c.transport.MaxConnsPerHost = n
+	}
+}
+
+// openBodyFile opens path and stats it, for streaming it as a request body
+// without reading it into memory first. The caller is responsible for
+// closing the returned file on any path that doesn't hand it to an
+// http.Request (whose Transport closes it once the request completes).
+func openBodyFile(path string) (*os.File, os.FileInfo, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("httpclient: opening body file: %w", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, nil, fmt.Errorf("httpclient: stat body file: %w", err)
+	}
+	return file, info, nil
+}
+
+// Send executes cfg and returns the parsed response.
+func (c *Client) Send(ctx context.Context, cfg RequestConfig) (*ResponseData, error) {
+	if c.rateLimiter != nil {
+		if err := c.rateLimiter.Wait(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	ctx, cancel := withRequestTimeout(ctx, cfg)
+	defer cancel()
+
+	limits := resolveLimits(cfg.Limits)
+	if cfg.MaxResponseBytes > 0 {
+		limits.MaxResponseBodyBytes = cfg.MaxResponseBytes
+	}
+
+	reqBody := cfg.Body
+	var autoContentType string
+	var bodyFile *os.File
+	var bodyReader io.Reader = strings.NewReader(reqBody)
+	var contentLength int64 = -1
+	switch {
+	case cfg.BodyFilePath != "":
+		file, info, err := openBodyFile(cfg.BodyFilePath)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkRequestBodyLength(info.Size(), limits); err != nil {
+			file.Close()
+			return nil, err
+		}
+		bodyFile = file
+		bodyReader = file
+		contentLength = info.Size()
+		autoContentType = "application/octet-stream"
+	case cfg.BodyIsBase64:
+		decoded, err := base64.StdEncoding.DecodeString(cfg.Body)
+		if err != nil {
+			return nil, fmt.Errorf("httpclient: body is not valid base64: %w", err)
+		}
+		reqBody = string(decoded)
+		bodyReader = strings.NewReader(reqBody)
+		autoContentType = "application/octet-stream"
+		if err := checkRequestBodySize(reqBody, limits); err != nil {
+			return nil, err
+		}
+	case len(cfg.FormData) > 0:
+		encoded, contentType, err := encodeMultipartFormData(cfg.FormData)
+		if err != nil {
+			return nil, err
+		}
+		reqBody = string(encoded)
+		bodyReader = strings.NewReader(reqBody)
+		autoContentType = contentType
+		if err := checkRequestBodySize(reqBody, limits); err != nil {
+			return nil, err
+		}
+	default:
+		if len(cfg.BodyTransforms) > 0 {
+			transformed, err := applyBodyTransforms(reqBody, cfg.BodyTransforms)
+			if err != nil {
+				return nil, err
+			}
+			reqBody = transformed
+			bodyReader = strings.NewReader(reqBody)
+		}
+		if err := checkRequestBodySize(reqBody, limits); err != nil {
+			return nil, err
+		}
+	}
+
+	ctx = withHeaderOrder(ctx, headerOrder(cfg.Headers))
+	ctx = withTlsConfig(ctx, c.effectiveTls(cfg.Tls))
+	tlsInfo := &TlsHandshakeInfo{}
+	ctx = withTlsInfoHolder(ctx, tlsInfo)
+	tracker := newTimingTracker(nil)
+	ctx = tracker.withContext(ctx)
+	var hops []RedirectHop
+	ctx = withRedirectHops(ctx, &hops)
+	var connHolder *rawConnHolder
+	if cfg.RetainRawConn {
+		connHolder = &rawConnHolder{}
+		ctx = withRawConnHolder(ctx, connHolder)
+	}
+	requestURL := cfg.URL
+	if httpURL, socketPath, uerr := rewriteUnixSocketURL(cfg.URL); uerr != nil {
+		if bodyFile != nil {
+			bodyFile.Close()
+		}
+		return nil, uerr
+	} else if httpURL != "" {
+		requestURL = httpURL
+		ctx = withUnixSocketPath(ctx, socketPath)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, cfg.Method, requestURL, bodyReader)
+	if err != nil {
+		if bodyFile != nil {
+			bodyFile.Close()
+		}
+		return nil, err
+	}
+	if contentLength >= 0 {
+		req.ContentLength = contentLength
+	}
+	switch {
+	case cfg.OmitContentLength:
+		req.ContentLength = 0
+	case cfg.ContentLengthOverride != nil:
+		req.ContentLength = *cfg.ContentLengthOverride
+	}
+	if cfg.BodyFilePath != "" {
+		// NewRequestWithContext only auto-populates GetBody for
+		// *bytes.Reader/*bytes.Buffer/*strings.Reader bodies, not an
+		// *os.File. Without it, a 307/308 redirect that needs to replay the
+		// body fails outright instead of reopening the file.
+		path := cfg.BodyFilePath
+		req.GetBody = func() (io.ReadCloser, error) {
+			file, _, err := openBodyFile(path)
+			return file, err
+		}
+	}
+	if autoContentType != "" && !hasHeader(cfg.Headers, "Content-Type") {
+		req.Header.Set("Content-Type", autoContentType)
+	}
+	// req.Header.Add preserves cfg.Headers' order only insofar as Go's map
+	// type allows - net/http.Transport re-sorts header names alphabetically
+	// when it writes the HTTP/1.1 request line (see RequestConfig.Headers'
+	// doc comment), so this loop does not give callers wire-level control
+	// over name order on the path Send actually uses.
+	for _, h := range cfg.Headers {
+		req.Header.Add(h.Name, h.Value)
+	}
+	applyHostHeaderOverride(req)
+	if cfg.GRPCWeb {
+		req.Header.Set("TE", "trailers")
+	}
+	if cfg.RetainRawConn {
+		// Forces the transport to give up the connection after this request
+		// instead of pooling it for reuse, so our loggedConn.Close override
+		// (which keeps the socket open for retention) doesn't risk the
+		// transport handing the same connection to an unrelated request.
+		req.Close = true
+	}
+	suppressHeaders(req, cfg.SuppressHeaders)
+
+	for _, interceptor := range c.interceptors {
+		interceptor.BeforeRequest(req)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil && isRefusedStreamError(err) && tlsInfo.NegotiatedALPN == "h2" && !forceHTTP1FromContext(ctx) {
+		if retryReq, rerr := cloneRequestForH1Retry(req); rerr == nil {
+			resp, err = c.httpClient.Do(retryReq)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if cfg.RetainRawConn && connHolder.conn != nil {
+		c.registerRawConn(cfg.RequestID, connHolder.conn)
+	}
+
+	for _, interceptor := range c.interceptors {
+		interceptor.AfterResponse(resp)
+	}
+
+	if err := checkResponseHeaderSize(resp.Header, limits); err != nil {
+		return nil, err
+	}
+
+	var body []byte
+	var incomplete bool
+	var truncationReason string
+	if limits.MaxResponseBodyBytes > 0 {
+		body, incomplete, truncationReason = readResponseBody(io.LimitReader(resp.Body, limits.MaxResponseBodyBytes+1))
+		if int64(len(body)) > limits.MaxResponseBodyBytes {
+			return nil, &LimitExceededError{Reason: "response_body"}
+		}
+	} else {
+		body, incomplete, truncationReason = readResponseBody(resp.Body)
+	}
+
+	body, err = decompressBody(body, resp.Header.Get("Content-Encoding"), limits.MaxResponseBodyBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := parseResponse(resp, body)
+	if err != nil {
+		return nil, err
+	}
+	data.Incomplete = incomplete
+	data.TruncationReason = truncationReason
+
+	if cfg.GRPCWeb {
+		status, err := parseGRPCWebTrailers(body, resp.Header.Get("Content-Type"))
+		if err != nil {
+			return nil, err
+		}
+		data.GRPCStatus = status
+	}
+
+	if tls := c.effectiveTls(cfg.Tls); tls != nil && tls.JA3 != "" {
+		data.JA3Hash = tlsfp.CalculateJA3Hash(tls.JA3)
+	}
+	if tlsInfo.Version != "" {
+		data.TlsInfo = tlsInfo
+	}
+	data.Timing = tracker.finish()
+	data.RedirectHops = hops
+	return data, nil
+}
+
+// registerRawConn retains conn under requestID for later RawConnLog/
+// ReleaseRawConn calls. An empty requestID has no way to be looked back up,
+// so conn is released immediately rather than leaked; the same happens to
+// whatever was previously retained under requestID, since letting it be
+// silently overwritten here would leak its connection too.
+func (c *Client) registerRawConn(requestID string, conn *loggedConn) {
+	if requestID == "" {
+		conn.release()
+		return
+	}
+	c.rawConnsMu.Lock()
+	defer c.rawConnsMu.Unlock()
+	if existing, ok := c.rawConns[requestID]; ok {
+		existing.release()
+	}
+	if c.rawConns == nil {
+		c.rawConns = make(map[string]*loggedConn)
+	}
+	c.rawConns[requestID] = conn
+}
+
+// RawConnLog returns the raw bytes read and written on the connection
+// RequestConfig.RetainRawConn retained under requestID, and whether one is
+// currently retained under that ID.
+func (c *Client) RawConnLog(requestID string) ([]byte, bool) {
+	c.rawConnsMu.Lock()
+	defer c.rawConnsMu.Unlock()
+	conn, ok := c.rawConns[requestID]
+	if !ok {
+		return nil, false
+	}
+	return conn.Bytes(), true
+}
+
+// ReleaseRawConn closes a connection retained under requestID and forgets
+// it, so callers doing protocol research don't leak the socket forever.
+// Returns false if nothing was retained under requestID.
+func (c *Client) ReleaseRawConn(requestID string) bool {
+	c.rawConnsMu.Lock()
+	conn, ok := c.rawConns[requestID]
+	if ok {
+		delete(c.rawConns, requestID)
+	}
+	c.rawConnsMu.Unlock()
+	if !ok {
+		return false
+	}
+	conn.release()
+	return true
+}
@@ -0,0 +1,422 @@
+package httpclient
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"httpcall/internal/tlsfp"
+)
+
+// TestSendRejectsTransferEncodingGzip documents a real stdlib limitation
+// hit while adding decompressTransferEncoding: net/http's client Transport
+// parses Transfer-Encoding itself and refuses anything but "chunked" before
+// a response ever reaches Client.Send, so a Transfer-Encoding: gzip server
+// can't be talked to over this path at all - see decompressTransferEncoding's
+// doc comment.
+func TestSendRejectsTransferEncodingGzip(t *testing.T) {
+	compressed := gzipCompress(t, []byte("hello"))
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hj, ok := w.(http.Hijacker)
+		if !ok {
+			t.Fatal("ResponseWriter doesn't support hijacking")
+		}
+		conn, buf, err := hj.Hijack()
+		if err != nil {
+			t.Fatalf("Hijack: %v", err)
+		}
+		defer conn.Close()
+		fmt.Fprintf(buf, "HTTP/1.1 200 OK\r\nTransfer-Encoding: gzip\r\nContent-Length: %d\r\nConnection: close\r\n\r\n", len(compressed))
+		buf.Write(compressed)
+		buf.Flush()
+	}))
+	defer srv.Close()
+
+	client := NewClient()
+	if _, err := client.Send(context.Background(), RequestConfig{Method: "GET", URL: srv.URL}); err == nil {
+		t.Fatal("Send: want an error for a Transfer-Encoding: gzip response, got nil")
+	}
+}
+
+func TestSendPerRequestTlsOverrideChangesJA3Hash(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	client := NewClientWithTls(&TlsConfig{JA3: tlsfp.Presets["chrome120"].JA3})
+
+	first, err := client.Send(context.Background(), RequestConfig{Method: "GET", URL: srv.URL})
+	if err != nil {
+		t.Fatalf("first Send: %v", err)
+	}
+
+	second, err := client.Send(context.Background(), RequestConfig{
+		Method: "GET",
+		URL:    srv.URL,
+		Tls:    &TlsConfig{JA3: tlsfp.Presets["firefox120"].JA3},
+	})
+	if err != nil {
+		t.Fatalf("second Send: %v", err)
+	}
+
+	if first.JA3Hash == "" || second.JA3Hash == "" {
+		t.Fatalf("expected non-empty JA3 hashes, got %q and %q", first.JA3Hash, second.JA3Hash)
+	}
+	if first.JA3Hash == second.JA3Hash {
+		t.Errorf("expected different JA3 hashes, both were %q", first.JA3Hash)
+	}
+}
+
+func TestSendReportsNoBodyFor204WithStrayContentEncoding(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Encoding", "gzip") // stray: some servers send this despite no body
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	client := NewClient()
+	resp, err := client.Send(context.Background(), RequestConfig{Method: "GET", URL: srv.URL})
+	if err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if resp.HasBody {
+		t.Error("HasBody = true, want false for a 204")
+	}
+	if resp.Body != "" {
+		t.Errorf("Body = %q, want empty", resp.Body)
+	}
+}
+
+func TestSendReportsNoBodyFor304WithStrayContentEncoding(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Encoding", "gzip")
+		w.WriteHeader(http.StatusNotModified)
+	}))
+	defer srv.Close()
+
+	client := NewClient()
+	resp, err := client.Send(context.Background(), RequestConfig{Method: "GET", URL: srv.URL})
+	if err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if resp.HasBody {
+		t.Error("HasBody = true, want false for a 304")
+	}
+}
+
+func TestSendReportsNoBodyForHEAD(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Encoding", "gzip")
+		w.Header().Set("Content-Length", "5")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	client := NewClient()
+	resp, err := client.Send(context.Background(), RequestConfig{Method: "HEAD", URL: srv.URL})
+	if err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if resp.HasBody {
+		t.Error("HasBody = true, want false for a HEAD response")
+	}
+}
+
+func TestSendReportsNoBodyForLegitimatelyEmpty200(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	client := NewClient()
+	resp, err := client.Send(context.Background(), RequestConfig{Method: "GET", URL: srv.URL})
+	if err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if resp.HasBody {
+		t.Error("HasBody = true, want false for an empty 200 body")
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("StatusCode = %d, want 200", resp.StatusCode)
+	}
+}
+
+func TestSendRetainRawConnLogIsRetrievableAndReleasable(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	client := NewClient()
+	resp, err := client.Send(context.Background(), RequestConfig{
+		Method:        "GET",
+		URL:           srv.URL,
+		RequestID:     "retain-1",
+		RetainRawConn: true,
+	})
+	if err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("StatusCode = %d, want 200", resp.StatusCode)
+	}
+
+	log, ok := client.RawConnLog("retain-1")
+	if !ok {
+		t.Fatal("RawConnLog: no connection retained under requestID")
+	}
+	if len(log) == 0 {
+		t.Error("RawConnLog: got empty log, want the TLS handshake/request bytes")
+	}
+
+	if !client.ReleaseRawConn("retain-1") {
+		t.Error("ReleaseRawConn: want true releasing a retained connection")
+	}
+	if client.ReleaseRawConn("retain-1") {
+		t.Error("ReleaseRawConn: want false releasing an already-released connection")
+	}
+	if _, ok := client.RawConnLog("retain-1"); ok {
+		t.Error("RawConnLog: want ok=false after release")
+	}
+}
+
+func TestSendContentLengthOverrideReachesServer(t *testing.T) {
+	var gotContentLength int64 = -2
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotContentLength = r.ContentLength
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	body := "hello"
+	override := int64(len(body))
+	client := NewClient()
+	if _, err := client.Send(context.Background(), RequestConfig{
+		Method:                "POST",
+		URL:                   srv.URL,
+		Body:                  body,
+		ContentLengthOverride: &override,
+	}); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if gotContentLength != override {
+		t.Errorf("server saw Content-Length %d, want %d", gotContentLength, override)
+	}
+}
+
+func TestSendOmitContentLengthForcesChunkedEncoding(t *testing.T) {
+	var gotTransferEncoding []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotTransferEncoding = r.TransferEncoding
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	client := NewClient()
+	if _, err := client.Send(context.Background(), RequestConfig{
+		Method:            "POST",
+		URL:               srv.URL,
+		Body:              "hello",
+		OmitContentLength: true,
+	}); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	found := false
+	for _, te := range gotTransferEncoding {
+		if te == "chunked" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("TransferEncoding = %v, want chunked", gotTransferEncoding)
+	}
+}
+
+func TestSendWithoutRetainRawConnLeavesNothingToLookUp(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	client := NewClient()
+	if _, err := client.Send(context.Background(), RequestConfig{Method: "GET", URL: srv.URL, RequestID: "no-retain"}); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if _, ok := client.RawConnLog("no-retain"); ok {
+		t.Error("RawConnLog: want ok=false when RetainRawConn wasn't set")
+	}
+}
+
+func TestSessionCookiesPersistAcrossSends(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.SetCookie(w, &http.Cookie{Name: "session", Value: "abc123"})
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	client := NewClient()
+	if _, err := client.Send(context.Background(), RequestConfig{Method: "GET", URL: srv.URL}); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+
+	cookies, err := client.SessionCookies(srv.URL)
+	if err != nil {
+		t.Fatalf("SessionCookies: %v", err)
+	}
+	if len(cookies) != 1 || cookies[0].Name != "session" || cookies[0].Value != "abc123" {
+		t.Errorf("SessionCookies = %+v, want one cookie session=abc123", cookies)
+	}
+}
+
+func TestSessionCookiesEmptyForUnvisitedURL(t *testing.T) {
+	client := NewClient()
+	cookies, err := client.SessionCookies("https://never-visited.example")
+	if err != nil {
+		t.Fatalf("SessionCookies: %v", err)
+	}
+	if len(cookies) != 0 {
+		t.Errorf("SessionCookies = %+v, want none", cookies)
+	}
+}
+
+// TestSendReportsDNSLookupForHostnameTarget asserts Send's Timing.DNSLookup
+// is populated for a hostname target - resolving "localhost" still goes
+// through net's resolver (and its httptrace DNSStart/DNSDone hooks) even
+// though it's satisfied locally, unlike an IP-literal target which skips
+// resolution (and the trace) entirely.
+func TestSendReportsDNSLookupForHostnameTarget(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	_, port, err := net.SplitHostPort(srv.Listener.Addr().String())
+	if err != nil {
+		t.Fatalf("SplitHostPort: %v", err)
+	}
+	hostnameURL := "http://localhost:" + port
+
+	client := NewClient()
+	data, err := client.Send(context.Background(), RequestConfig{Method: "GET", URL: hostnameURL})
+	if err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if data.Timing.DNSLookup <= 0 {
+		t.Errorf("Timing.DNSLookup = %v, want > 0 for a hostname target", data.Timing.DNSLookup)
+	}
+}
+
+// TestSendReportsNoDNSLookupForIPLiteralTarget asserts Timing.DNSLookup
+// stays zero for an IP-literal target, since net's resolver never invokes
+// the DNS trace hooks for one.
+func TestSendReportsNoDNSLookupForIPLiteralTarget(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	client := NewClient()
+	data, err := client.Send(context.Background(), RequestConfig{Method: "GET", URL: srv.URL})
+	if err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if data.Timing.DNSLookup != 0 {
+		t.Errorf("Timing.DNSLookup = %v, want 0 for an IP-literal target", data.Timing.DNSLookup)
+	}
+}
+
+// TestSendTimeoutMsAbortsSlowRequest asserts a short RequestConfig.TimeoutMs
+// cuts off a request against a slow endpoint instead of waiting for
+// defaultRequestTimeout.
+func TestSendTimeoutMsAbortsSlowRequest(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(2 * time.Second)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	client := NewClient()
+	start := time.Now()
+	_, err := client.Send(context.Background(), RequestConfig{Method: "GET", URL: srv.URL, TimeoutMs: 100})
+	if err == nil {
+		t.Fatal("Send: want a timeout error, got nil")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Send took %v, want it to abort near the 100ms TimeoutMs", elapsed)
+	}
+}
+
+// TestSendMarksIncompleteWhenBodyClosesMidStream simulates a connection
+// dropped mid-body (a GOAWAY, RST_STREAM, or plain read error all surface
+// to Send the same way net/http does: a Read error on resp.Body) by
+// declaring a Content-Length the server then doesn't deliver before closing
+// the connection.
+func TestSendMarksIncompleteWhenBodyClosesMidStream(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hj, ok := w.(http.Hijacker)
+		if !ok {
+			t.Fatal("ResponseWriter doesn't support hijacking")
+		}
+		conn, buf, err := hj.Hijack()
+		if err != nil {
+			t.Fatalf("Hijack: %v", err)
+		}
+		defer conn.Close()
+		fmt.Fprintf(buf, "HTTP/1.1 200 OK\r\nContent-Length: 100\r\n\r\n")
+		buf.WriteString("short")
+		buf.Flush()
+	}))
+	defer srv.Close()
+
+	client := NewClient()
+	data, err := client.Send(context.Background(), RequestConfig{Method: "GET", URL: srv.URL})
+	if err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if !data.Incomplete {
+		t.Error("Incomplete = false, want true for a body cut short mid-stream")
+	}
+	if data.TruncationReason == "" {
+		t.Error("TruncationReason is empty, want the read error that cut the body short")
+	}
+	if data.Body != "short" {
+		t.Errorf("Body = %q, want the partial bytes received (%q)", data.Body, "short")
+	}
+}
+
+// TestSendDuplicateHeaderValuesBothReachServer exercises the case
+// map[string]string couldn't represent at all: two values for the same
+// header name. RequestConfig.Headers being an ordered []HeaderField (added
+// alongside this test) means both reach the wire via http.Header.Add
+// instead of the second silently overwriting the first.
+func TestSendDuplicateHeaderValuesBothReachServer(t *testing.T) {
+	var got []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Values("X-Forwarded-For")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	client := NewClient()
+	_, err := client.Send(context.Background(), RequestConfig{
+		Method: "GET",
+		URL:    srv.URL,
+		Headers: []HeaderField{
+			{Name: "X-Forwarded-For", Value: "10.0.0.1"},
+			{Name: "X-Forwarded-For", Value: "10.0.0.2"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	want := []string{"10.0.0.1", "10.0.0.2"}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("X-Forwarded-For values = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,130 @@
+package httpclient
+
+import (
+	"sort"
+	"time"
+)
+
+// ConnEvent is one timestamped milestone captured while establishing a
+// connection (DNS lookup, TCP connect, TLS handshake, etc).
+type ConnEvent struct {
+	// ConnIndex identifies which connection attempt this event belongs to.
+	// 0 is the first attempt; HTTP/2-over-h1-fallback and Happy Eyeballs
+	// races both produce more than one attempt, so events from different
+	// connections need to be told apart once merged onto one timeline.
+	ConnIndex int
+	Name      string
+	// Offset is this event's time since its own connection attempt started
+	// (i.e. relative to that attempt's t=0, not to the overall request).
+	Offset time.Duration
+	// Bytes is the wire size of this event's TLS record, when Name names a
+	// byte-counted handshake flight (see the handshake event name
+	// constants below). Zero for milestones that aren't a wire record
+	// (e.g. "connect_start").
+	Bytes int
+}
+
+// Handshake event names Summary recognizes. Other ConnEvent.Name values
+// (e.g. "connect_start", "handshake_done") are preserved in the raw trace
+// but don't contribute to the derived summary.
+const (
+	ClientHelloSent           = "client_hello_sent"
+	HelloRetryRequestReceived = "hello_retry_request_received"
+	ServerHelloReceived       = "server_hello_received"
+	CertificateReceived       = "certificate_received"
+	FinishedSent              = "finished_sent"
+	FinishedReceived          = "finished_received"
+)
+
+// ConnTrace is the raw per-connection event log, plus when that connection
+// attempt itself started relative to the overall request.
+type ConnTrace struct {
+	ConnIndex int
+	Start     time.Duration
+	Events    []ConnEvent
+}
+
+// ConnTraceSummary is a quick handshake-health view derived from a
+// ConnTrace's raw events, so a caller doesn't have to read every event to
+// answer "did the handshake look normal".
+type ConnTraceSummary struct {
+	// RoundTrips counts the server flights the client had to wait for: one
+	// for an ordinary handshake's ServerHello, plus one more for each
+	// HelloRetryRequest the server sent first (e.g. when it needs a
+	// different key share than the client offered).
+	RoundTrips int
+	// BytesSent/BytesReceived total the Bytes recorded on every
+	// "_sent"/"_received" handshake event.
+	BytesSent     int
+	BytesReceived int
+	// TimeToServerHello is the offset of the ServerHelloReceived event, or
+	// zero if the trace doesn't have one (the handshake never got that
+	// far).
+	TimeToServerHello time.Duration
+	// Resumed reports whether this looks like an abbreviated/PSK-resumed
+	// handshake rather than a full one: it's inferred from a
+	// ServerHelloReceived event with no accompanying CertificateReceived,
+	// since a full handshake always sends a certificate and a resumed one
+	// never does.
+	Resumed bool
+}
+
+// Summary derives a ConnTraceSummary from t's raw Events.
+func (t ConnTrace) Summary() ConnTraceSummary {
+	var s ConnTraceSummary
+	var sawServerHello, sawCertificate bool
+
+	for _, ev := range t.Events {
+		switch ev.Name {
+		case HelloRetryRequestReceived:
+			s.RoundTrips++
+			s.BytesReceived += ev.Bytes
+		case ServerHelloReceived:
+			s.RoundTrips++
+			sawServerHello = true
+			s.TimeToServerHello = ev.Offset
+			s.BytesReceived += ev.Bytes
+		case CertificateReceived:
+			sawCertificate = true
+			s.BytesReceived += ev.Bytes
+		case ClientHelloSent, FinishedSent:
+			s.BytesSent += ev.Bytes
+		case FinishedReceived:
+			s.BytesReceived += ev.Bytes
+		}
+	}
+
+	s.Resumed = sawServerHello && !sawCertificate
+	return s
+}
+
+// mergeConnEntries combines several connections' traces into one timeline,
+// expressed relative to the overall request's start. A dual-connection
+// fallback (e.g. an h1 attempt that's abandoned mid-handshake once h2
+// becomes viable over a second connection) produces one ConnTrace per
+// attempt, each with its own Start; naively concatenating Offsets without
+// adding Start back in collapses both onto t=0 and makes the second
+// connection look like it started before the first one finished dialing.
+// Negative results (a clock-skewed or malformed event) are clamped to 0
+// rather than shown as happening before the connection existed.
+func mergeConnEntries(traces []ConnTrace) []ConnEvent {
+	var merged []ConnEvent
+	for _, trace := range traces {
+		for _, ev := range trace.Events {
+			absolute := trace.Start + ev.Offset
+			if absolute < 0 {
+				absolute = 0
+			}
+			merged = append(merged, ConnEvent{
+				ConnIndex: trace.ConnIndex,
+				Name:      ev.Name,
+				Offset:    absolute,
+			})
+		}
+	}
+
+	sort.SliceStable(merged, func(i, j int) bool {
+		return merged[i].Offset < merged[j].Offset
+	})
+	return merged
+}
@@ -0,0 +1,129 @@
+package httpclient
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMergeConnEntriesAlignsDualConnectionTimeline(t *testing.T) {
+	traces := []ConnTrace{
+		{
+			ConnIndex: 0,
+			Start:     0,
+			Events: []ConnEvent{
+				{Name: "connect_start", Offset: 0},
+				{Name: "handshake_abandoned", Offset: 20 * time.Millisecond},
+			},
+		},
+		{
+			// Second connection started 10ms after the request began,
+			// while the first was still dialing.
+			ConnIndex: 1,
+			Start:     10 * time.Millisecond,
+			Events: []ConnEvent{
+				{Name: "connect_start", Offset: 0},
+				{Name: "handshake_done", Offset: 15 * time.Millisecond},
+			},
+		},
+	}
+
+	merged := mergeConnEntries(traces)
+	if len(merged) != 4 {
+		t.Fatalf("got %d events, want 4", len(merged))
+	}
+
+	want := []struct {
+		name      string
+		connIndex int
+		offset    time.Duration
+	}{
+		{"connect_start", 0, 0},
+		{"connect_start", 1, 10 * time.Millisecond},
+		{"handshake_abandoned", 0, 20 * time.Millisecond},
+		{"handshake_done", 1, 25 * time.Millisecond},
+	}
+	for i, w := range want {
+		if merged[i].Name != w.name || merged[i].ConnIndex != w.connIndex || merged[i].Offset != w.offset {
+			t.Errorf("event %d = %+v, want %+v", i, merged[i], w)
+		}
+	}
+}
+
+func TestMergeConnEntriesClampsNegativeOffsets(t *testing.T) {
+	traces := []ConnTrace{
+		{ConnIndex: 0, Start: 5 * time.Millisecond, Events: []ConnEvent{{Name: "skewed", Offset: -10 * time.Millisecond}}},
+	}
+	merged := mergeConnEntries(traces)
+	if len(merged) != 1 || merged[0].Offset != 0 {
+		t.Fatalf("got %+v, want one event clamped to 0", merged)
+	}
+}
+
+func TestConnTraceSummaryForFullHandshake(t *testing.T) {
+	trace := ConnTrace{
+		ConnIndex: 0,
+		Events: []ConnEvent{
+			{Name: ClientHelloSent, Offset: 0, Bytes: 200},
+			{Name: ServerHelloReceived, Offset: 10 * time.Millisecond, Bytes: 90},
+			{Name: CertificateReceived, Offset: 12 * time.Millisecond, Bytes: 1500},
+			{Name: FinishedReceived, Offset: 14 * time.Millisecond, Bytes: 40},
+			{Name: FinishedSent, Offset: 15 * time.Millisecond, Bytes: 40},
+			{Name: "handshake_done", Offset: 15 * time.Millisecond},
+		},
+	}
+
+	summary := trace.Summary()
+	if summary.RoundTrips != 1 {
+		t.Errorf("RoundTrips = %d, want 1 for a full handshake with no HelloRetryRequest", summary.RoundTrips)
+	}
+	if summary.Resumed {
+		t.Error("Resumed = true, want false: a CertificateReceived event was recorded")
+	}
+	if summary.TimeToServerHello != 10*time.Millisecond {
+		t.Errorf("TimeToServerHello = %v, want 10ms", summary.TimeToServerHello)
+	}
+	if want := 240; summary.BytesSent != want {
+		t.Errorf("BytesSent = %d, want %d", summary.BytesSent, want)
+	}
+	if want := 1630; summary.BytesReceived != want {
+		t.Errorf("BytesReceived = %d, want %d", summary.BytesReceived, want)
+	}
+}
+
+func TestConnTraceSummaryForResumedHandshakeHasNoCertificate(t *testing.T) {
+	trace := ConnTrace{
+		Events: []ConnEvent{
+			{Name: ClientHelloSent, Offset: 0, Bytes: 210},
+			{Name: ServerHelloReceived, Offset: 8 * time.Millisecond, Bytes: 90},
+			{Name: FinishedReceived, Offset: 9 * time.Millisecond, Bytes: 40},
+			{Name: FinishedSent, Offset: 10 * time.Millisecond, Bytes: 40},
+		},
+	}
+
+	summary := trace.Summary()
+	if !summary.Resumed {
+		t.Error("Resumed = false, want true: no CertificateReceived event was recorded")
+	}
+	if summary.RoundTrips != 1 {
+		t.Errorf("RoundTrips = %d, want 1", summary.RoundTrips)
+	}
+}
+
+func TestConnTraceSummaryCountsHelloRetryRequestAsExtraRoundTrip(t *testing.T) {
+	trace := ConnTrace{
+		Events: []ConnEvent{
+			{Name: ClientHelloSent, Offset: 0, Bytes: 200},
+			{Name: HelloRetryRequestReceived, Offset: 5 * time.Millisecond, Bytes: 60},
+			{Name: ClientHelloSent, Offset: 6 * time.Millisecond, Bytes: 250},
+			{Name: ServerHelloReceived, Offset: 16 * time.Millisecond, Bytes: 90},
+			{Name: CertificateReceived, Offset: 18 * time.Millisecond, Bytes: 1500},
+			{Name: FinishedReceived, Offset: 20 * time.Millisecond, Bytes: 40},
+			{Name: FinishedSent, Offset: 21 * time.Millisecond, Bytes: 40},
+		},
+	}
+
+	summary := trace.Summary()
+	if summary.RoundTrips != 2 {
+		t.Errorf("RoundTrips = %d, want 2 (one HelloRetryRequest, one ServerHello)", summary.RoundTrips)
+	}
+}
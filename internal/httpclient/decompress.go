@@ -0,0 +1,108 @@
+package httpclient
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// decompressBody reverses the Content-Encoding applied to body. Multiple,
+// comma-separated encodings (e.g. "gzip, br") are applied server-side in
+// order, so they must be undone in reverse order: the last encoding listed
+// was applied last and must be stripped first. An empty body skips
+// decompression entirely rather than erroring - a 204/304/HEAD response can
+// carry a stray Content-Encoding header despite never sending a body.
+//
+// maxBytes caps each stage's decompressed output the same way Client.Send
+// already caps the compressed bytes read off the wire - without it, a small
+// gzip/deflate bomb can expand to an arbitrary size and OOM the process,
+// which matters here since this client is meant to talk to untrusted
+// targets. maxBytes <= 0 means unlimited.
+func decompressBody(body []byte, contentEncoding string, maxBytes int64) ([]byte, error) {
+	if contentEncoding == "" || len(body) == 0 {
+		return body, nil
+	}
+
+	encodings := strings.Split(contentEncoding, ",")
+	for i := len(encodings) - 1; i >= 0; i-- {
+		enc := strings.ToLower(strings.TrimSpace(encodings[i]))
+		if enc == "" || enc == "identity" {
+			continue
+		}
+		decoded, err := decompressOne(body, enc, maxBytes)
+		if err != nil {
+			return nil, fmt.Errorf("decompressing %q stage: %w", enc, err)
+		}
+		body = decoded
+	}
+	return body, nil
+}
+
+// decompressTransferEncoding reverses a "gzip" entry in transferEncoding -
+// distinct from Content-Encoding (see decompressBody) and rare in practice,
+// but RFC 7230 §3.3.1 allows a server to apply it. It isn't reachable from
+// Client.Send today: net/http's client Transport parses Transfer-Encoding
+// itself and hard-fails the request ("unsupported transfer encoding") for
+// anything but "chunked" before a response ever reaches our code, so a real
+// Transfer-Encoding: gzip server can't be talked to through the stdlib path
+// at all. This is the decompression half ready for a transport that parses
+// the wire itself (e.g. a future CustomH2Transport.RoundTrip, which speaks
+// HTTP/2 and has no such restriction on request). Returns whether it found
+// (and undid) a gzip entry, so a caller can report it separately from
+// Content-Encoding.
+func decompressTransferEncoding(body []byte, transferEncoding []string, maxBytes int64) ([]byte, bool, error) {
+	found := false
+	for _, te := range transferEncoding {
+		if strings.EqualFold(strings.TrimSpace(te), "gzip") {
+			found = true
+			break
+		}
+	}
+	if !found || len(body) == 0 {
+		return body, false, nil
+	}
+
+	decoded, err := decompressOne(body, "gzip", maxBytes)
+	if err != nil {
+		return nil, false, fmt.Errorf("decompressing transfer-encoding gzip: %w", err)
+	}
+	return decoded, true, nil
+}
+
+// decompressOne decompresses body under encoding, stopping with a
+// *LimitExceededError as soon as the decompressed output would exceed
+// maxBytes (<= 0 means unlimited) instead of letting io.ReadAll keep
+// growing its buffer for the whole (potentially bomb-sized) output.
+func decompressOne(body []byte, encoding string, maxBytes int64) ([]byte, error) {
+	var r io.Reader
+	switch encoding {
+	case "gzip", "x-gzip":
+		gz, err := gzip.NewReader(bytes.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+		defer gz.Close()
+		r = gz
+	case "deflate":
+		fl := flate.NewReader(bytes.NewReader(body))
+		defer fl.Close()
+		r = fl
+	default:
+		return nil, fmt.Errorf("unsupported content-encoding %q", encoding)
+	}
+
+	if maxBytes <= 0 {
+		return io.ReadAll(r)
+	}
+	decoded, err := io.ReadAll(io.LimitReader(r, maxBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(decoded)) > maxBytes {
+		return nil, &LimitExceededError{Reason: "response_body"}
+	}
+	return decoded, nil
+}
@@ -0,0 +1,154 @@
+package httpclient
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"errors"
+	"testing"
+)
+
+func gzipCompress(t *testing.T, data []byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	if _, err := w.Write(data); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func deflateCompress(t *testing.T, data []byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w, err := flate.NewWriter(&buf, flate.DefaultCompression)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.Write(data); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestDecompressBodyStackedEncodings(t *testing.T) {
+	original := []byte(`{"hello":"world"}`)
+	// Server applied deflate first, then gzip on top, so Content-Encoding
+	// lists them in application order: "deflate, gzip". Decoding must undo
+	// gzip (applied last) before deflate.
+	deflated := deflateCompress(t, original)
+	stacked := gzipCompress(t, deflated)
+
+	got, err := decompressBody(stacked, "deflate, gzip", 0)
+	if err != nil {
+		t.Fatalf("decompressBody: %v", err)
+	}
+	if !bytes.Equal(got, original) {
+		t.Errorf("got %q, want %q", got, original)
+	}
+}
+
+func TestDecompressBodySingleEncoding(t *testing.T) {
+	original := []byte("plain text body")
+	compressed := gzipCompress(t, original)
+
+	got, err := decompressBody(compressed, "gzip", 0)
+	if err != nil {
+		t.Fatalf("decompressBody: %v", err)
+	}
+	if !bytes.Equal(got, original) {
+		t.Errorf("got %q, want %q", got, original)
+	}
+}
+
+func TestDecompressBodyNoEncoding(t *testing.T) {
+	original := []byte("untouched")
+	got, err := decompressBody(original, "", 0)
+	if err != nil {
+		t.Fatalf("decompressBody: %v", err)
+	}
+	if !bytes.Equal(got, original) {
+		t.Errorf("got %q, want %q", got, original)
+	}
+}
+
+func TestDecompressBodyEmptyBodySkipsDecompression(t *testing.T) {
+	got, err := decompressBody(nil, "gzip", 0)
+	if err != nil {
+		t.Fatalf("decompressBody: %v", err)
+	}
+	if len(got) != 0 {
+		t.Errorf("got %v, want empty", got)
+	}
+}
+
+func TestDecompressTransferEncodingUndoesGzip(t *testing.T) {
+	original := []byte("plain text body")
+	compressed := gzipCompress(t, original)
+
+	got, found, err := decompressTransferEncoding(compressed, []string{"gzip"}, 0)
+	if err != nil {
+		t.Fatalf("decompressTransferEncoding: %v", err)
+	}
+	if !found {
+		t.Error("found = false, want true")
+	}
+	if !bytes.Equal(got, original) {
+		t.Errorf("got %q, want %q", got, original)
+	}
+}
+
+func TestDecompressTransferEncodingNoGzipIsNoop(t *testing.T) {
+	original := []byte("plain text body")
+	got, found, err := decompressTransferEncoding(original, []string{"chunked"}, 0)
+	if err != nil {
+		t.Fatalf("decompressTransferEncoding: %v", err)
+	}
+	if found {
+		t.Error("found = true, want false")
+	}
+	if !bytes.Equal(got, original) {
+		t.Errorf("got %q, want %q", got, original)
+	}
+}
+
+func TestDecompressBodyRejectsDecompressionBomb(t *testing.T) {
+	// A few KB of repeated bytes gzip down to almost nothing but expand well
+	// past a small maxBytes budget - the shape of a decompression bomb.
+	bomb := gzipCompress(t, bytes.Repeat([]byte{'A'}, 1<<20))
+
+	_, err := decompressBody(bomb, "gzip", 1024)
+	var limitErr *LimitExceededError
+	if !errors.As(err, &limitErr) {
+		t.Fatalf("decompressBody: got %v, want a *LimitExceededError", err)
+	}
+}
+
+func TestDecompressBodyAllowsOutputUnderLimit(t *testing.T) {
+	original := []byte("small body")
+	compressed := gzipCompress(t, original)
+
+	got, err := decompressBody(compressed, "gzip", int64(len(original)))
+	if err != nil {
+		t.Fatalf("decompressBody: %v", err)
+	}
+	if !bytes.Equal(got, original) {
+		t.Errorf("got %q, want %q", got, original)
+	}
+}
+
+func TestDecompressTransferEncodingRejectsDecompressionBomb(t *testing.T) {
+	bomb := gzipCompress(t, bytes.Repeat([]byte{'A'}, 1<<20))
+
+	_, _, err := decompressTransferEncoding(bomb, []string{"gzip"}, 1024)
+	var limitErr *LimitExceededError
+	if !errors.As(err, &limitErr) {
+		t.Fatalf("decompressTransferEncoding: got %v, want a *LimitExceededError", err)
+	}
+}
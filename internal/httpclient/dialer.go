@@ -0,0 +1,75 @@
+package httpclient
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"time"
+)
+
+// HappyEyeballsDelay staggers successive connection attempts (RFC 8305
+// recommends ~250ms) so a slow-to-resolve-but-fine first address doesn't
+// block on a single attempt before trying the next one.
+var HappyEyeballsDelay = 250 * time.Millisecond
+
+type lookupFunc func(ctx context.Context, host string) ([]net.IPAddr, error)
+
+// dialHappyEyeballs resolves addr's host to all its IPs and races
+// connection attempts against them, staggered by HappyEyeballsDelay,
+// returning the first to succeed and cancelling the rest.
+func dialHappyEyeballs(ctx context.Context, dialer *net.Dialer, network, addr string, cache *DNSCache) (net.Conn, error) {
+	lookup := lookupFunc(net.DefaultResolver.LookupIPAddr)
+	if cache != nil {
+		lookup = cache.wrap(lookup)
+	}
+	return dialHappyEyeballsWithLookup(ctx, dialer, network, addr, lookup)
+}
+
+func dialHappyEyeballsWithLookup(ctx context.Context, dialer *net.Dialer, network, addr string, lookup lookupFunc) (net.Conn, error) {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, err
+	}
+	ips, err := lookup(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+	if len(ips) == 0 {
+		return nil, fmt.Errorf("no addresses found for %s", host)
+	}
+
+	type result struct {
+		conn net.Conn
+		err  error
+	}
+	resCh := make(chan result, len(ips))
+	raceCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	for i, ip := range ips {
+		i, ip := i, ip
+		go func() {
+			select {
+			case <-time.After(time.Duration(i) * HappyEyeballsDelay):
+			case <-raceCtx.Done():
+				resCh <- result{nil, raceCtx.Err()}
+				return
+			}
+			conn, err := dialer.DialContext(raceCtx, network, net.JoinHostPort(ip.String(), port))
+			resCh <- result{conn, err}
+		}()
+	}
+
+	var firstErr error
+	for range ips {
+		r := <-resCh
+		if r.err == nil {
+			cancel()
+			return r.conn, nil
+		}
+		if firstErr == nil {
+			firstErr = r.err
+		}
+	}
+	return nil, firstErr
+}
@@ -0,0 +1,56 @@
+package httpclient
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestDialHappyEyeballsFallsBackPastDeadAddress(t *testing.T) {
+	// Both candidates share one port (as real Happy Eyeballs candidates
+	// do); 127.0.0.2 has a listener, 127.0.0.3 does not, so connecting to
+	// it fails fast (connection refused) and the racer must fall back.
+	good, err := net.Listen("tcp", "127.0.0.2:0")
+	if err != nil {
+		t.Skipf("cannot bind 127.0.0.2 in this sandbox: %v", err)
+	}
+	defer good.Close()
+	go func() {
+		for {
+			c, err := good.Accept()
+			if err != nil {
+				return
+			}
+			c.Close()
+		}
+	}()
+
+	port := good.Addr().(*net.TCPAddr).Port
+
+	lookup := func(ctx context.Context, host string) ([]net.IPAddr, error) {
+		return []net.IPAddr{
+			{IP: net.ParseIP("127.0.0.3")},
+			{IP: net.ParseIP("127.0.0.2")},
+		}, nil
+	}
+
+	origDelay := HappyEyeballsDelay
+	HappyEyeballsDelay = 10 * time.Millisecond
+	defer func() { HappyEyeballsDelay = origDelay }()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	addr := net.JoinHostPort("placeholder-host", strconv.Itoa(port))
+	conn, err := dialHappyEyeballsWithLookup(ctx, &net.Dialer{}, "tcp", addr, lookup)
+	if err != nil {
+		t.Fatalf("dialHappyEyeballsWithLookup: %v", err)
+	}
+	defer conn.Close()
+
+	if conn.RemoteAddr().(*net.TCPAddr).IP.String() != "127.0.0.2" {
+		t.Errorf("connected to %v, want 127.0.0.2", conn.RemoteAddr())
+	}
+}
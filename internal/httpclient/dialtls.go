@@ -0,0 +1,367 @@
+package httpclient
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"httpcall/internal/tlsfp"
+)
+
+type ctxKey int
+
+const (
+	tlsConfigCtxKey     ctxKey = 0
+	tlsInfoCtxKey       ctxKey = 1
+	redirectHopsCtxKey  ctxKey = 2
+	rawConnHolderCtxKey ctxKey = 3
+	forceHTTP1CtxKey    ctxKey = 4
+)
+
+// withForceHTTP1 marks ctx so dialTLS negotiates http/1.1 only, regardless
+// of cfg's configured ALPN list - used by the REFUSED_STREAM h1 fallback
+// retry (see isRefusedStreamError) to make sure the replayed request can't
+// land back on HTTP/2 and hit the same server-side h2 bot-detection rule.
+func withForceHTTP1(ctx context.Context) context.Context {
+	return context.WithValue(ctx, forceHTTP1CtxKey, true)
+}
+
+func forceHTTP1FromContext(ctx context.Context) bool {
+	forced, _ := ctx.Value(forceHTTP1CtxKey).(bool)
+	return forced
+}
+
+func withTlsConfig(ctx context.Context, cfg *TlsConfig) context.Context {
+	return context.WithValue(ctx, tlsConfigCtxKey, cfg)
+}
+
+func tlsConfigFromContext(ctx context.Context) *TlsConfig {
+	cfg, _ := ctx.Value(tlsConfigCtxKey).(*TlsConfig)
+	return cfg
+}
+
+// withTlsInfoHolder attaches an empty *TlsHandshakeInfo to ctx for dialTLS
+// to fill in once the handshake completes. A context value is the only way
+// to get data out of dialTLS, since http.Transport's DialTLSContext signature
+// only returns a net.Conn.
+func withTlsInfoHolder(ctx context.Context, holder *TlsHandshakeInfo) context.Context {
+	return context.WithValue(ctx, tlsInfoCtxKey, holder)
+}
+
+func tlsInfoHolderFromContext(ctx context.Context) *TlsHandshakeInfo {
+	holder, _ := ctx.Value(tlsInfoCtxKey).(*TlsHandshakeInfo)
+	return holder
+}
+
+// dialTLS dials addr and performs the TLS handshake, choosing SNI via
+// chooseSNI so IP-literal targets don't send an invalid ServerName.
+func (c *Client) dialTLS(ctx context.Context, network, addr string) (net.Conn, error) {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		host = addr
+	}
+
+	cfg := tlsConfigFromContext(ctx)
+	if cfg == nil {
+		cfg = c.defaultTls
+	}
+	serverName := chooseSNI(host, cfg)
+
+	nextProtos := alpnProtocols(cfg)
+	if forceHTTP1FromContext(ctx) {
+		nextProtos = []string{"http/1.1"}
+	}
+	tlsCfg := &tls.Config{ServerName: serverName, CurvePreferences: curvePreferences(cfg), NextProtos: nextProtos, Renegotiation: renegotiationSupport(cfg), ClientSessionCache: c.sessionCache}
+	if cfg != nil && (cfg.ClientCertPEM != "" || cfg.ClientKeyPEM != "") {
+		clientCert, err := tls.X509KeyPair([]byte(cfg.ClientCertPEM), []byte(cfg.ClientKeyPEM))
+		if err != nil {
+			return nil, &TlsHandshakeError{Category: TlsErrorClientCertInvalid, Err: err}
+		}
+		tlsCfg.Certificates = []tls.Certificate{clientCert}
+	}
+	if serverName == "" {
+		// No hostname to verify a certificate against; this mirrors what
+		// most HTTP clients do for bare-IP HTTPS targets.
+		tlsCfg.InsecureSkipVerify = true
+	}
+	if cfg != nil && cfg.SkipVerify {
+		tlsCfg.InsecureSkipVerify = true
+	}
+	if cfg != nil && cfg.RootCAPEM != "" {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM([]byte(cfg.RootCAPEM)) {
+			return nil, &TlsHandshakeError{Category: TlsErrorRootCAInvalid, Err: errors.New("no certificates found in RootCAPEM")}
+		}
+		tlsCfg.RootCAs = pool
+	}
+
+	rawConn, err := (&net.Dialer{}).DialContext(ctx, network, addr)
+	if err != nil {
+		return nil, err
+	}
+
+	var logged *loggedConn
+	if holder := rawConnHolderFromContext(ctx); holder != nil {
+		logged = newLoggedConn(rawConn)
+		rawConn = logged
+	}
+
+	handshakeCtx, cancel := context.WithTimeout(ctx, handshakeTimeout(cfg))
+	defer cancel()
+
+	conn := tls.Client(rawConn, tlsCfg)
+	if err := conn.HandshakeContext(handshakeCtx); err != nil {
+		rawConn.Close()
+		if handshakeCtx.Err() == context.DeadlineExceeded {
+			return nil, &TlsHandshakeError{Category: TlsErrorHandshakeTimeout, Err: handshakeCtx.Err()}
+		}
+		return nil, ClassifyTlsError(err)
+	}
+
+	if holder := tlsInfoHolderFromContext(ctx); holder != nil {
+		*holder = buildTlsHandshakeInfo(conn.ConnectionState(), cfg, serverName != "")
+	}
+	if holder := rawConnHolderFromContext(ctx); holder != nil {
+		holder.conn = logged
+	}
+	return conn, nil
+}
+
+// handshakeTimeout returns cfg's configured handshake timeout, or
+// defaultHandshakeTimeout if cfg is nil or left it at zero.
+func handshakeTimeout(cfg *TlsConfig) time.Duration {
+	if cfg != nil && cfg.HandshakeTimeout > 0 {
+		return cfg.HandshakeTimeout
+	}
+	return defaultHandshakeTimeout
+}
+
+// curvePreferences translates cfg's wire-value curve list into the
+// crypto/tls.CurveID type, falling back to Go's default order when cfg
+// doesn't request a specific one.
+func curvePreferences(cfg *TlsConfig) []tls.CurveID {
+	if cfg == nil || len(cfg.CurvePreferences) == 0 {
+		return nil
+	}
+	curves := make([]tls.CurveID, len(cfg.CurvePreferences))
+	for i, id := range cfg.CurvePreferences {
+		curves[i] = tls.CurveID(id)
+	}
+	return curves
+}
+
+// renegotiationSupport translates cfg's RenegotiationSupport into
+// crypto/tls's own type, defaulting to RenegotiateNever (crypto/tls's
+// default too) when cfg is nil.
+func renegotiationSupport(cfg *TlsConfig) tls.RenegotiationSupport {
+	if cfg == nil {
+		return tls.RenegotiateNever
+	}
+	switch cfg.Renegotiation {
+	case RenegotiateOnceAsClient:
+		return tls.RenegotiateOnceAsClient
+	case RenegotiateFreely:
+		return tls.RenegotiateFreelyAsClient
+	default:
+		return tls.RenegotiateNever
+	}
+}
+
+// alpnProtocols returns cfg's configured ALPN list (tlsfp.ALPNProtocols'
+// default when cfg doesn't set one), for use as tls.Config.NextProtos.
+func alpnProtocols(cfg *TlsConfig) []string {
+	if cfg == nil {
+		return tlsfp.ALPNProtocols(tlsfp.ExtensionConfig{})
+	}
+	return tlsfp.ALPNProtocols(tlsfp.ExtensionConfig{ALPN: cfg.ALPN, RawMode: cfg.RawMode})
+}
+
+// buildTlsHandshakeInfo summarizes conn's negotiated TLS parameters. See
+// TlsHandshakeInfo.NegotiatedGroup for why the group is inferred rather
+// than read directly off the connection.
+func buildTlsHandshakeInfo(state tls.ConnectionState, cfg *TlsConfig, sniPresent bool) TlsHandshakeInfo {
+	info := TlsHandshakeInfo{
+		Version:        tlsVersionName(state.Version),
+		NegotiatedALPN: state.NegotiatedProtocol,
+		ALPNOffered:    alpnProtocols(cfg),
+	}
+	if cfg != nil && cfg.EnableEarlyData {
+		info.EarlyDataOffered = true
+	}
+	if cfg != nil && len(cfg.CurvePreferences) > 0 {
+		info.NegotiatedGroup = tlsfp.GroupName(cfg.CurvePreferences[0])
+	} else {
+		info.NegotiatedGroup = tlsfp.GroupName(tlsfp.GroupX25519) // crypto/tls's default first preference
+	}
+	info.JA4Hash = resolveJA4(cfg, state, sniPresent)
+	info.JA4Raw = resolveJA4Raw(cfg, state, sniPresent)
+	info.ServerCert = buildServerCertInfo(state)
+	if cfg != nil && cfg.SkipVerify {
+		info.VerificationSkipped = true
+	}
+	info.PointFormats = resolvePointFormats(cfg)
+	return info
+}
+
+// resolvePointFormats resolves the ec_point_formats list to report for cfg:
+// an explicit TlsConfig.PointFormats override takes precedence, then
+// whatever JA3's own point-formats field specified, then tlsfp's default -
+// honored even when JA3 left that field blank, since real clients commonly
+// do while still sending the extension.
+func resolvePointFormats(cfg *TlsConfig) []uint8 {
+	if cfg == nil {
+		return tlsfp.PointFormats(tlsfp.ExtensionConfig{})
+	}
+	if len(cfg.PointFormats) > 0 {
+		return tlsfp.PointFormats(tlsfp.ExtensionConfig{PointFormats: cfg.PointFormats, RawMode: cfg.RawMode})
+	}
+	if cfg.JA3 != "" {
+		if fields, err := tlsfp.ParseJA3Text(cfg.JA3); err == nil {
+			if parsed := parseUint8List(fields.PointFormats); len(parsed) > 0 {
+				return tlsfp.PointFormats(tlsfp.ExtensionConfig{PointFormats: parsed, RawMode: cfg.RawMode})
+			}
+		}
+	}
+	return tlsfp.PointFormats(tlsfp.ExtensionConfig{RawMode: cfg.RawMode})
+}
+
+// parseUint8List converts JA3Fields.PointFormats' decimal string components
+// into uint8s, skipping any that don't parse.
+func parseUint8List(values []string) []uint8 {
+	out := make([]uint8, 0, len(values))
+	for _, v := range values {
+		n, err := strconv.ParseUint(v, 10, 8)
+		if err != nil {
+			continue
+		}
+		out = append(out, uint8(n))
+	}
+	return out
+}
+
+// buildServerCertInfo summarizes the leaf certificate from state, or returns
+// nil if the peer sent none (e.g. a resumed session whose handshake skipped
+// the Certificate message entirely).
+func buildServerCertInfo(state tls.ConnectionState) *ServerCertInfo {
+	if len(state.PeerCertificates) == 0 {
+		return nil
+	}
+	leaf := state.PeerCertificates[0]
+	return &ServerCertInfo{
+		SubjectCN: leaf.Subject.CommonName,
+		IssuerCN:  leaf.Issuer.CommonName,
+		SANs:      leaf.DNSNames,
+		NotBefore: leaf.NotBefore,
+		NotAfter:  leaf.NotAfter,
+	}
+}
+
+// resolveJA4Params builds the tlsfp.JA4Params cfg.JA3 describes, for use by
+// both resolveJA4 and resolveJA4Raw. The second return is false if cfg.JA3
+// wasn't configured or didn't parse, in which case neither caller has
+// anything to compute from.
+func resolveJA4Params(cfg *TlsConfig, state tls.ConnectionState, sniPresent bool) (tlsfp.JA4Params, bool) {
+	if cfg == nil || cfg.JA3 == "" {
+		return tlsfp.JA4Params{}, false
+	}
+	fields, err := tlsfp.ParseJA3Text(cfg.JA3)
+	if err != nil {
+		return tlsfp.JA4Params{}, false
+	}
+	return tlsfp.JA4Params{
+		Protocol:     "t",
+		TLSVersion:   state.Version,
+		SNIPresent:   sniPresent,
+		CipherSuites: parseUint16List(fields.Ciphers),
+		Extensions:   parseUint16List(fields.Extensions),
+		ALPN:         state.NegotiatedProtocol,
+	}, true
+}
+
+// resolveJA4 returns cfg.CustomJA4 when set, otherwise derives JA4 from
+// cfg.JA3's cipher/extension lists (see tlsfp.CalculateJA4). Returns "" if
+// neither JA3 nor CustomJA4 were configured, or JA3 doesn't parse.
+func resolveJA4(cfg *TlsConfig, state tls.ConnectionState, sniPresent bool) string {
+	if cfg != nil && cfg.CustomJA4 != "" {
+		return cfg.CustomJA4
+	}
+	params, ok := resolveJA4Params(cfg, state, sniPresent)
+	if !ok {
+		return ""
+	}
+	return tlsfp.CalculateJA4(params)
+}
+
+// resolveJA4Raw returns the JA4_r ("raw") variant alongside resolveJA4's
+// hashed one (see tlsfp.CalculateJA4Raw). Returns "" whenever resolveJA4
+// would fall back to cfg.CustomJA4, since an opaque custom hash has no raw
+// cipher/extension list to spell out.
+func resolveJA4Raw(cfg *TlsConfig, state tls.ConnectionState, sniPresent bool) string {
+	if cfg != nil && cfg.CustomJA4 != "" {
+		return ""
+	}
+	params, ok := resolveJA4Params(cfg, state, sniPresent)
+	if !ok {
+		return ""
+	}
+	return tlsfp.CalculateJA4Raw(params)
+}
+
+// parseUint16List converts JA3Fields' decimal string components (already
+// validated by ParseJA3Text) into uint16s, skipping any that don't parse.
+func parseUint16List(values []string) []uint16 {
+	out := make([]uint16, 0, len(values))
+	for _, v := range values {
+		n, err := strconv.ParseUint(v, 10, 16)
+		if err != nil {
+			continue
+		}
+		out = append(out, uint16(n))
+	}
+	return out
+}
+
+func tlsVersionName(version uint16) string {
+	switch version {
+	case tls.VersionTLS13:
+		return "TLS 1.3"
+	case tls.VersionTLS12:
+		return "TLS 1.2"
+	case tls.VersionTLS11:
+		return "TLS 1.1"
+	case tls.VersionTLS10:
+		return "TLS 1.0"
+	default:
+		return "unknown"
+	}
+}
+
+// chooseSNI decides the SNI ServerName for host. IP-literal targets
+// normally omit SNI entirely (RFC 6066 doesn't allow IP addresses there,
+// and some servers reject one with an unrecognized_name alert), unless an
+// explicit override is configured.
+func chooseSNI(host string, cfg *TlsConfig) string {
+	if cfg != nil && cfg.SNIOverride != "" {
+		return cfg.SNIOverride
+	}
+	if isIPLiteral(host) {
+		return ""
+	}
+	return host
+}
+
+// isIPLiteral reports whether host identifies an IP address rather than a
+// hostname. net.ParseIP alone doesn't recognize a zoned IPv6 literal like
+// "fe80::1%eth0" (net.SplitHostPort leaves the zone suffix in place), so
+// the zone is trimmed before the check.
+func isIPLiteral(host string) bool {
+	if i := strings.IndexByte(host, '%'); i != -1 {
+		host = host[:i]
+	}
+	return net.ParseIP(host) != nil
+}
@@ -0,0 +1,147 @@
+package httpclient
+
+import (
+	"crypto/tls"
+	"strings"
+	"testing"
+
+	"httpcall/internal/tlsfp"
+)
+
+func TestBuildTlsHandshakeInfoUsesConfiguredCurve(t *testing.T) {
+	cfg := &TlsConfig{CurvePreferences: []uint16{tlsfp.GroupSecp256r1, tlsfp.GroupX25519}}
+	info := buildTlsHandshakeInfo(tls.ConnectionState{Version: tls.VersionTLS13, NegotiatedProtocol: "h2"}, cfg, true)
+
+	if info.Version != "TLS 1.3" {
+		t.Errorf("Version = %q, want TLS 1.3", info.Version)
+	}
+	if info.NegotiatedALPN != "h2" {
+		t.Errorf("NegotiatedALPN = %q, want h2", info.NegotiatedALPN)
+	}
+	if info.NegotiatedGroup != "secp256r1" {
+		t.Errorf("NegotiatedGroup = %q, want secp256r1", info.NegotiatedGroup)
+	}
+}
+
+func TestBuildTlsHandshakeInfoDefaultsGroupWithNoConfig(t *testing.T) {
+	info := buildTlsHandshakeInfo(tls.ConnectionState{Version: tls.VersionTLS12}, nil, false)
+	if info.NegotiatedGroup != "X25519" {
+		t.Errorf("NegotiatedGroup = %q, want X25519", info.NegotiatedGroup)
+	}
+	if info.Version != "TLS 1.2" {
+		t.Errorf("Version = %q, want TLS 1.2", info.Version)
+	}
+	if info.JA4Hash != "" {
+		t.Errorf("JA4Hash = %q, want empty with no JA3/CustomJA4 configured", info.JA4Hash)
+	}
+}
+
+func TestBuildTlsHandshakeInfoDefaultsPointFormatsWithNoConfig(t *testing.T) {
+	info := buildTlsHandshakeInfo(tls.ConnectionState{Version: tls.VersionTLS13}, nil, false)
+	if len(info.PointFormats) != 1 || info.PointFormats[0] != 0 {
+		t.Errorf("PointFormats = %v, want [0]", info.PointFormats)
+	}
+}
+
+func TestBuildTlsHandshakeInfoPointFormatsOverrideHonoredEvenWhenJA3OmitsThem(t *testing.T) {
+	// JA3's point-formats field (the fifth, trailing comma-group) is left
+	// empty here, mirroring real clients that omit it while still sending
+	// the extension; the explicit override must still win.
+	cfg := &TlsConfig{JA3: "771,4865-4866-4867,0-23-65281,29-23-24,", PointFormats: []uint8{0, 1, 2}}
+	info := buildTlsHandshakeInfo(tls.ConnectionState{Version: tls.VersionTLS12}, cfg, true)
+
+	want := []uint8{0, 1, 2}
+	if len(info.PointFormats) != len(want) {
+		t.Fatalf("PointFormats = %v, want %v", info.PointFormats, want)
+	}
+	for i := range want {
+		if info.PointFormats[i] != want[i] {
+			t.Fatalf("PointFormats = %v, want %v", info.PointFormats, want)
+		}
+	}
+}
+
+func TestBuildTlsHandshakeInfoFallsBackToJA3PointFormats(t *testing.T) {
+	cfg := &TlsConfig{JA3: "771,4865-4866-4867,0-23-65281,29-23-24,0-1-2"}
+	info := buildTlsHandshakeInfo(tls.ConnectionState{Version: tls.VersionTLS12}, cfg, true)
+
+	want := []uint8{0, 1, 2}
+	if len(info.PointFormats) != len(want) {
+		t.Fatalf("PointFormats = %v, want %v", info.PointFormats, want)
+	}
+	for i := range want {
+		if info.PointFormats[i] != want[i] {
+			t.Fatalf("PointFormats = %v, want %v", info.PointFormats, want)
+		}
+	}
+}
+
+func TestBuildTlsHandshakeInfoDerivesJA4FromJA3(t *testing.T) {
+	cfg := &TlsConfig{JA3: "771,4865-4866-4867,0-23-65281,29-23-24,0"}
+	info := buildTlsHandshakeInfo(tls.ConnectionState{Version: tls.VersionTLS13, NegotiatedProtocol: "h2"}, cfg, true)
+	if !strings.HasPrefix(info.JA4Hash, "t13d0303h2_") {
+		t.Errorf("JA4Hash = %q, want prefix t13d0303h2_", info.JA4Hash)
+	}
+}
+
+func TestBuildTlsHandshakeInfoCustomJA4Overrides(t *testing.T) {
+	cfg := &TlsConfig{JA3: "771,4865,0,29,0", CustomJA4: "t13d0001h2_abc_def"}
+	info := buildTlsHandshakeInfo(tls.ConnectionState{Version: tls.VersionTLS13}, cfg, true)
+	if info.JA4Hash != "t13d0001h2_abc_def" {
+		t.Errorf("JA4Hash = %q, want CustomJA4 value", info.JA4Hash)
+	}
+}
+
+func TestBuildTlsHandshakeInfoDerivesJA4RawAlongsideJA4Hash(t *testing.T) {
+	cfg := &TlsConfig{JA3: "771,4865-4866-4867,0-23-65281,29-23-24,0"}
+	info := buildTlsHandshakeInfo(tls.ConnectionState{Version: tls.VersionTLS13, NegotiatedProtocol: "h2"}, cfg, true)
+	if !strings.HasPrefix(info.JA4Raw, "t13d0303h2_4865,4866,4867_") {
+		t.Errorf("JA4Raw = %q, want prefix t13d0303h2_4865,4866,4867_", info.JA4Raw)
+	}
+}
+
+func TestBuildTlsHandshakeInfoJA4RawEmptyWhenCustomJA4Set(t *testing.T) {
+	cfg := &TlsConfig{JA3: "771,4865,0,29,0", CustomJA4: "t13d0001h2_abc_def"}
+	info := buildTlsHandshakeInfo(tls.ConnectionState{Version: tls.VersionTLS13}, cfg, true)
+	if info.JA4Raw != "" {
+		t.Errorf("JA4Raw = %q, want empty when CustomJA4 overrides JA4Hash", info.JA4Raw)
+	}
+}
+
+func TestBuildTlsHandshakeInfoJA4RawEmptyWithNoConfig(t *testing.T) {
+	info := buildTlsHandshakeInfo(tls.ConnectionState{Version: tls.VersionTLS12}, nil, false)
+	if info.JA4Raw != "" {
+		t.Errorf("JA4Raw = %q, want empty with no JA3/CustomJA4 configured", info.JA4Raw)
+	}
+}
+
+func TestBuildTlsHandshakeInfoALPNOfferedMatchesConfig(t *testing.T) {
+	cfg := &TlsConfig{ALPN: []string{"http/1.1"}}
+	info := buildTlsHandshakeInfo(tls.ConnectionState{Version: tls.VersionTLS12, NegotiatedProtocol: "http/1.1"}, cfg, true)
+
+	want := []string{"http/1.1"}
+	if len(info.ALPNOffered) != len(want) || info.ALPNOffered[0] != want[0] {
+		t.Errorf("ALPNOffered = %v, want %v", info.ALPNOffered, want)
+	}
+}
+
+func TestBuildTlsHandshakeInfoALPNOfferedDefaultsWithNoConfig(t *testing.T) {
+	info := buildTlsHandshakeInfo(tls.ConnectionState{Version: tls.VersionTLS13}, nil, false)
+
+	want := []string{"h2", "http/1.1"}
+	if len(info.ALPNOffered) != len(want) {
+		t.Fatalf("ALPNOffered = %v, want %v", info.ALPNOffered, want)
+	}
+	for i := range want {
+		if info.ALPNOffered[i] != want[i] {
+			t.Fatalf("ALPNOffered = %v, want %v", info.ALPNOffered, want)
+		}
+	}
+}
+
+func TestBuildTlsHandshakeInfoNoCertificatesLeavesServerCertNil(t *testing.T) {
+	info := buildTlsHandshakeInfo(tls.ConnectionState{Version: tls.VersionTLS13}, nil, false)
+	if info.ServerCert != nil {
+		t.Errorf("ServerCert = %+v, want nil", info.ServerCert)
+	}
+}
@@ -0,0 +1,72 @@
+package httpclient
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSendToIPLiteralHTTPSTargetSucceeds(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close() // srv.URL is https://127.0.0.1:<port>, an IP literal
+
+	client := NewClient()
+	resp, err := client.Send(context.Background(), RequestConfig{Method: "GET", URL: srv.URL})
+	if err != nil {
+		t.Fatalf("Send to IP-literal HTTPS target failed: %v", err)
+	}
+	if resp.StatusCode != 200 {
+		t.Errorf("StatusCode = %d, want 200", resp.StatusCode)
+	}
+}
+
+func TestChooseSNI(t *testing.T) {
+	if got := chooseSNI("93.184.216.34", nil); got != "" {
+		t.Errorf("IP literal: got %q, want empty", got)
+	}
+	if got := chooseSNI("example.com", nil); got != "example.com" {
+		t.Errorf("hostname: got %q, want example.com", got)
+	}
+	if got := chooseSNI("93.184.216.34", &TlsConfig{SNIOverride: "example.com"}); got != "example.com" {
+		t.Errorf("override: got %q, want example.com", got)
+	}
+	if got := chooseSNI("::1", nil); got != "" {
+		t.Errorf("IPv6 literal: got %q, want empty", got)
+	}
+	if got := chooseSNI("fe80::1%eth0", nil); got != "" {
+		t.Errorf("zoned IPv6 literal: got %q, want empty", got)
+	}
+}
+
+func TestRenegotiationSupportDefaultsToNever(t *testing.T) {
+	if got := renegotiationSupport(nil); got != tls.RenegotiateNever {
+		t.Errorf("renegotiationSupport(nil) = %v, want RenegotiateNever", got)
+	}
+	if got := renegotiationSupport(&TlsConfig{}); got != tls.RenegotiateNever {
+		t.Errorf("renegotiationSupport(zero value) = %v, want RenegotiateNever", got)
+	}
+}
+
+func TestRenegotiationSupportHonorsConfig(t *testing.T) {
+	if got := renegotiationSupport(&TlsConfig{Renegotiation: RenegotiateOnceAsClient}); got != tls.RenegotiateOnceAsClient {
+		t.Errorf("RenegotiateOnceAsClient => %v, want tls.RenegotiateOnceAsClient", got)
+	}
+	if got := renegotiationSupport(&TlsConfig{Renegotiation: RenegotiateFreely}); got != tls.RenegotiateFreelyAsClient {
+		t.Errorf("RenegotiateFreely => %v, want tls.RenegotiateFreelyAsClient", got)
+	}
+}
+
+// TestSendToServerRequiringRenegotiationHonorsConfig would exercise
+// RenegotiateOnceAsClient against a real server-initiated renegotiation,
+// but crypto/tls's server side has no support for initiating
+// renegotiation at all (only limited client-side support exists) - so
+// there is no way to build that server with this toolchain. Skipped
+// rather than faked; renegotiationSupport's mapping above is what's
+// actually reachable and tested.
+func TestSendToServerRequiringRenegotiationHonorsConfig(t *testing.T) {
+	t.Skip("crypto/tls has no server-side support for initiating TLS renegotiation; cannot construct this server locally")
+}
@@ -0,0 +1,66 @@
+package httpclient
+
+import (
+	"context"
+	"net"
+	"sync"
+	"time"
+)
+
+// DNSCache memoizes lookupFunc resolutions by hostname for its configured
+// TTL, so repeated lookups of the same host through it don't re-resolve DNS
+// each time (dialHappyEyeballs is the lookup path that uses it). Failed lookups
+// are cached too (negative caching): a host that just failed to resolve is
+// unlikely to succeed moments later, and retrying it immediately costs as
+// much resolver load as never caching at all.
+type DNSCache struct {
+	ttl time.Duration
+
+	mu      sync.Mutex
+	entries map[string]dnsCacheEntry
+}
+
+type dnsCacheEntry struct {
+	ips     []net.IPAddr
+	err     error
+	expires time.Time
+}
+
+// NewDNSCache returns a DNSCache that remembers each host's resolution (or
+// resolution failure) for ttl. A non-positive ttl disables caching: every
+// lookup passes straight through.
+func NewDNSCache(ttl time.Duration) *DNSCache {
+	return &DNSCache{ttl: ttl, entries: make(map[string]dnsCacheEntry)}
+}
+
+// Clear discards every cached resolution, e.g. after a network change where
+// stale entries would otherwise outlive their usefulness.
+func (d *DNSCache) Clear() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.entries = make(map[string]dnsCacheEntry)
+}
+
+// wrap returns a lookupFunc that serves host resolutions from d's cache
+// while they're fresh, falling through to lookup (and caching its result,
+// success or failure) on a miss or expiry.
+func (d *DNSCache) wrap(lookup lookupFunc) lookupFunc {
+	return func(ctx context.Context, host string) ([]net.IPAddr, error) {
+		if d.ttl <= 0 {
+			return lookup(ctx, host)
+		}
+
+		d.mu.Lock()
+		entry, ok := d.entries[host]
+		d.mu.Unlock()
+		if ok && timeNow().Before(entry.expires) {
+			return entry.ips, entry.err
+		}
+
+		ips, err := lookup(ctx, host)
+		d.mu.Lock()
+		d.entries[host] = dnsCacheEntry{ips: ips, err: err, expires: timeNow().Add(d.ttl)}
+		d.mu.Unlock()
+		return ips, err
+	}
+}
@@ -0,0 +1,133 @@
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+)
+
+func TestDNSCacheServesRepeatedLookupsFromCacheWithinTTL(t *testing.T) {
+	defer func(orig func() time.Time) { timeNow = orig }(timeNow)
+	now := time.Unix(0, 0)
+	timeNow = func() time.Time { return now }
+
+	calls := 0
+	want := []net.IPAddr{{IP: net.ParseIP("93.184.216.34")}}
+	lookup := func(ctx context.Context, host string) ([]net.IPAddr, error) {
+		calls++
+		return want, nil
+	}
+
+	cache := NewDNSCache(time.Minute)
+	wrapped := cache.wrap(lookup)
+
+	for i := 0; i < 5; i++ {
+		ips, err := wrapped(context.Background(), "example.com")
+		if err != nil {
+			t.Fatalf("lookup %d: %v", i, err)
+		}
+		if len(ips) != 1 || ips[0].IP.String() != want[0].IP.String() {
+			t.Fatalf("lookup %d: got %v, want %v", i, ips, want)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("resolver called %d times for 5 lookups within TTL, want 1", calls)
+	}
+}
+
+func TestDNSCacheReResolvesAfterTTLExpires(t *testing.T) {
+	defer func(orig func() time.Time) { timeNow = orig }(timeNow)
+	now := time.Unix(0, 0)
+	timeNow = func() time.Time { return now }
+
+	calls := 0
+	lookup := func(ctx context.Context, host string) ([]net.IPAddr, error) {
+		calls++
+		return []net.IPAddr{{IP: net.ParseIP("10.0.0.1")}}, nil
+	}
+
+	cache := NewDNSCache(time.Minute)
+	wrapped := cache.wrap(lookup)
+
+	if _, err := wrapped(context.Background(), "example.com"); err != nil {
+		t.Fatal(err)
+	}
+	now = now.Add(2 * time.Minute)
+	if _, err := wrapped(context.Background(), "example.com"); err != nil {
+		t.Fatal(err)
+	}
+	if calls != 2 {
+		t.Errorf("resolver called %d times across a TTL expiry, want 2", calls)
+	}
+}
+
+func TestDNSCacheNegativeCachesFailedLookups(t *testing.T) {
+	defer func(orig func() time.Time) { timeNow = orig }(timeNow)
+	timeNow = func() time.Time { return time.Unix(0, 0) }
+
+	wantErr := errors.New("no such host")
+	calls := 0
+	lookup := func(ctx context.Context, host string) ([]net.IPAddr, error) {
+		calls++
+		return nil, wantErr
+	}
+
+	cache := NewDNSCache(time.Minute)
+	wrapped := cache.wrap(lookup)
+
+	for i := 0; i < 3; i++ {
+		if _, err := wrapped(context.Background(), "bad.invalid"); !errors.Is(err, wantErr) {
+			t.Fatalf("lookup %d: err = %v, want %v", i, err, wantErr)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("resolver called %d times for a negatively-cached host, want 1", calls)
+	}
+}
+
+func TestDNSCacheClearForcesReResolve(t *testing.T) {
+	defer func(orig func() time.Time) { timeNow = orig }(timeNow)
+	timeNow = func() time.Time { return time.Unix(0, 0) }
+
+	calls := 0
+	lookup := func(ctx context.Context, host string) ([]net.IPAddr, error) {
+		calls++
+		return []net.IPAddr{{IP: net.ParseIP("10.0.0.1")}}, nil
+	}
+
+	cache := NewDNSCache(time.Minute)
+	wrapped := cache.wrap(lookup)
+
+	if _, err := wrapped(context.Background(), "example.com"); err != nil {
+		t.Fatal(err)
+	}
+	cache.Clear()
+	if _, err := wrapped(context.Background(), "example.com"); err != nil {
+		t.Fatal(err)
+	}
+	if calls != 2 {
+		t.Errorf("resolver called %d times after Clear, want 2", calls)
+	}
+}
+
+func TestDNSCacheDisabledWhenTTLNonPositive(t *testing.T) {
+	calls := 0
+	lookup := func(ctx context.Context, host string) ([]net.IPAddr, error) {
+		calls++
+		return []net.IPAddr{{IP: net.ParseIP("10.0.0.1")}}, nil
+	}
+
+	cache := NewDNSCache(0)
+	wrapped := cache.wrap(lookup)
+
+	for i := 0; i < 3; i++ {
+		if _, err := wrapped(context.Background(), "example.com"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if calls != 3 {
+		t.Errorf("resolver called %d times with caching disabled, want 3", calls)
+	}
+}
@@ -0,0 +1,58 @@
+package httpclient
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"testing"
+	"time"
+)
+
+// errAbandoned is what a caller's onProgress returns to stop Download
+// early, e.g. after sampling enough of the body to know it's unwanted.
+var errAbandoned = errors.New("abandoned")
+
+func TestDownloadAbandonedMidStreamDoesNotLeakGoroutines(t *testing.T) {
+	payload := bytes.Repeat([]byte("x"), 5*1024*1024) // big enough to span many chunks
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(payload)
+	}))
+	defer srv.Close()
+
+	before := runtime.NumGoroutine()
+
+	client := NewClient()
+	var dest bytes.Buffer
+	var calls int
+	_, err := client.Download(context.Background(), RequestConfig{Method: "GET", URL: srv.URL}, &dest, func(DownloadProgress) error {
+		calls++
+		if calls == 2 {
+			return errAbandoned
+		}
+		return nil
+	})
+	if !errors.Is(err, errAbandoned) {
+		t.Fatalf("err = %v, want errAbandoned", err)
+	}
+	if dest.Len() >= len(payload) {
+		t.Fatalf("wrote %d bytes, want fewer than the full %d - body should have been abandoned early", dest.Len(), len(payload))
+	}
+
+	// Give the runtime a moment to actually tear down anything left over
+	// from the aborted request (connection close, transport bookkeeping)
+	// before checking that no goroutines were leaked.
+	var after int
+	for i := 0; i < 50; i++ {
+		after = runtime.NumGoroutine()
+		if after <= before {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if after > before {
+		t.Errorf("goroutine count = %d, want <= %d (before the download)", after, before)
+	}
+}
@@ -0,0 +1,98 @@
+package httpclient
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestClientDownloadStreamsAndHashesBody(t *testing.T) {
+	payload := bytes.Repeat([]byte("x"), 5*1024*1024) // 5MB
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(payload)
+	}))
+	defer srv.Close()
+
+	var dest bytes.Buffer
+	var progressCalls int
+	client := NewClient()
+	result, err := client.Download(context.Background(), RequestConfig{Method: "GET", URL: srv.URL}, &dest, func(DownloadProgress) error {
+		progressCalls++
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Download: %v", err)
+	}
+	if dest.Len() != len(payload) {
+		t.Fatalf("wrote %d bytes, want %d", dest.Len(), len(payload))
+	}
+	if progressCalls == 0 {
+		t.Error("expected at least one progress callback")
+	}
+
+	wantHash := sha256.Sum256(payload)
+	if result.SHA256 != hex.EncodeToString(wantHash[:]) {
+		t.Errorf("SHA256 = %s, want %s", result.SHA256, hex.EncodeToString(wantHash[:]))
+	}
+	if result.BytesWritten != int64(len(payload)) {
+		t.Errorf("BytesWritten = %d, want %d", result.BytesWritten, len(payload))
+	}
+}
+
+func TestClientDownloadChunkedBodyReportsProgressAndDuration(t *testing.T) {
+	const chunks = 4
+	const chunkPayload = "abcdefgh" // 8 bytes/chunk
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		flusher := w.(http.Flusher)
+		for i := 0; i < chunks; i++ {
+			w.Write([]byte(chunkPayload))
+			flusher.Flush()
+			time.Sleep(5 * time.Millisecond)
+		}
+	}))
+	defer srv.Close()
+
+	var dest bytes.Buffer
+	var progress []DownloadProgress
+	client := NewClient()
+	result, err := client.Download(context.Background(), RequestConfig{
+		Method:         "GET",
+		URL:            srv.URL,
+		ChunkSizeBytes: len(chunkPayload),
+	}, &dest, func(p DownloadProgress) error {
+		progress = append(progress, p)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Download: %v", err)
+	}
+
+	if len(progress) < chunks {
+		t.Errorf("got %d progress callbacks, want at least %d", len(progress), chunks)
+	}
+	if result.BytesWritten != int64(chunks*len(chunkPayload)) {
+		t.Errorf("BytesWritten = %d, want %d", result.BytesWritten, chunks*len(chunkPayload))
+	}
+	if result.DurationMillis <= 0 {
+		t.Errorf("DurationMillis = %d, want > 0", result.DurationMillis)
+	}
+}
+
+func TestClientDownloadRejectsCompressedResponses(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Encoding", "gzip")
+		w.Write([]byte("not actually gzipped, doesn't matter"))
+	}))
+	defer srv.Close()
+
+	client := NewClient()
+	var dest bytes.Buffer
+	if _, err := client.Download(context.Background(), RequestConfig{Method: "GET", URL: srv.URL}, &dest, nil); err == nil {
+		t.Fatal("expected an error for a compressed response")
+	}
+}
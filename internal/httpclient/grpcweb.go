@@ -0,0 +1,73 @@
+package httpclient
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// grpcWebTrailerFlag marks a gRPC-Web frame as the trailer frame rather
+// than a data frame, per the high bit of its 1-byte header (RFC: gRPC-Web
+// spec, "5.1 trailer frame").
+const grpcWebTrailerFlag = 0x80
+
+// GRPCWebStatus is the grpc-status/grpc-message pair a gRPC-Web server
+// reports in its trailer frame.
+type GRPCWebStatus struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// parseGRPCWebTrailers scans a gRPC-Web response body for its trailer frame
+// and extracts grpc-status/grpc-message from it. Browsers can't read real
+// HTTP/2 trailers, so gRPC-Web instead appends one more length-prefixed
+// frame to the body - flagged by grpcWebTrailerFlag - containing the
+// trailers as HTTP/1-style header lines. contentType selects whether the
+// whole body is base64 text (application/grpc-web-text) or raw framed
+// bytes (application/grpc-web); returns nil, nil if body has no trailer
+// frame at all.
+func parseGRPCWebTrailers(body []byte, contentType string) (*GRPCWebStatus, error) {
+	if strings.Contains(contentType, "text") {
+		decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(body)))
+		if err != nil {
+			return nil, fmt.Errorf("httpclient: grpc-web-text body is not valid base64: %w", err)
+		}
+		body = decoded
+	}
+
+	for len(body) >= 5 {
+		flag := body[0]
+		length := binary.BigEndian.Uint32(body[1:5])
+		if uint64(len(body)-5) < uint64(length) {
+			break
+		}
+		payload := body[5 : 5+length]
+		if flag&grpcWebTrailerFlag != 0 {
+			return parseGRPCWebTrailerHeaders(payload), nil
+		}
+		body = body[5+length:]
+	}
+	return nil, nil
+}
+
+// parseGRPCWebTrailerHeaders parses a trailer frame's payload, which is
+// plain HTTP/1-style "key: value\r\n" lines rather than a binary format.
+func parseGRPCWebTrailerHeaders(payload []byte) *GRPCWebStatus {
+	status := &GRPCWebStatus{}
+	for _, line := range strings.Split(string(payload), "\r\n") {
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		switch strings.ToLower(strings.TrimSpace(key)) {
+		case "grpc-status":
+			status.Code, _ = strconv.Atoi(value)
+		case "grpc-message":
+			status.Message = value
+		}
+	}
+	return status
+}
@@ -0,0 +1,76 @@
+package httpclient
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// grpcWebFrame builds a single length-prefixed gRPC-Web frame.
+func grpcWebFrame(flag byte, payload []byte) []byte {
+	frame := make([]byte, 5+len(payload))
+	frame[0] = flag
+	binary.BigEndian.PutUint32(frame[1:5], uint32(len(payload)))
+	copy(frame[5:], payload)
+	return frame
+}
+
+func TestParseGRPCWebTrailersExtractsStatusAndMessage(t *testing.T) {
+	data := grpcWebFrame(0x00, []byte("hello"))
+	trailer := grpcWebFrame(grpcWebTrailerFlag, []byte("grpc-status: 5\r\ngrpc-message: not found\r\n"))
+	body := append(data, trailer...)
+
+	status, err := parseGRPCWebTrailers(body, "application/grpc-web+proto")
+	if err != nil {
+		t.Fatalf("parseGRPCWebTrailers: %v", err)
+	}
+	if status == nil {
+		t.Fatal("status = nil, want a trailer")
+	}
+	if status.Code != 5 {
+		t.Errorf("Code = %d, want 5", status.Code)
+	}
+	if status.Message != "not found" {
+		t.Errorf("Message = %q, want %q", status.Message, "not found")
+	}
+}
+
+func TestParseGRPCWebTrailersDecodesBase64Text(t *testing.T) {
+	trailer := grpcWebFrame(grpcWebTrailerFlag, []byte("grpc-status: 0\r\ngrpc-message: OK\r\n"))
+	encoded := base64.StdEncoding.EncodeToString(trailer)
+
+	status, err := parseGRPCWebTrailers([]byte(encoded), "application/grpc-web-text+proto")
+	if err != nil {
+		t.Fatalf("parseGRPCWebTrailers: %v", err)
+	}
+	if status == nil || status.Message != "OK" {
+		t.Fatalf("status = %+v, want grpc-message OK", status)
+	}
+}
+
+func TestSendGRPCWebSetsTEHeaderAndParsesTrailers(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("TE"); got != "trailers" {
+			t.Errorf("TE header = %q, want trailers", got)
+		}
+		w.Header().Set("Content-Type", "application/grpc-web+proto")
+		trailer := grpcWebFrame(grpcWebTrailerFlag, []byte("grpc-status: 3\r\ngrpc-message: bad request\r\n"))
+		w.Write(trailer)
+	}))
+	defer srv.Close()
+
+	client := NewClient()
+	resp, err := client.Send(context.Background(), RequestConfig{Method: "GET", URL: srv.URL, GRPCWeb: true})
+	if err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if resp.GRPCStatus == nil {
+		t.Fatal("GRPCStatus = nil, want a parsed trailer")
+	}
+	if resp.GRPCStatus.Code != 3 || resp.GRPCStatus.Message != "bad request" {
+		t.Errorf("GRPCStatus = %+v, want {3 bad request}", resp.GRPCStatus)
+	}
+}
@@ -0,0 +1,100 @@
+package httpclient
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestIsRefusedStreamErrorMatchesWrappedH2Error(t *testing.T) {
+	wrapped := fmt.Errorf("Post \"https://example.com\": %w", errors.New("stream error: stream ID 1; REFUSED_STREAM"))
+	if !isRefusedStreamError(wrapped) {
+		t.Error("isRefusedStreamError = false, want true for a REFUSED_STREAM stream error")
+	}
+
+	goAway := errors.New(`http2: server sent GOAWAY and closed the connection; LastStreamID=1, ErrCode=REFUSED_STREAM, debug=""`)
+	if !isRefusedStreamError(goAway) {
+		t.Error("isRefusedStreamError = false, want true for a REFUSED_STREAM GOAWAY")
+	}
+}
+
+func TestIsRefusedStreamErrorRejectsUnrelatedErrors(t *testing.T) {
+	if isRefusedStreamError(errors.New("connection reset by peer")) {
+		t.Error("isRefusedStreamError = true, want false for an unrelated error")
+	}
+	if isRefusedStreamError(nil) {
+		t.Error("isRefusedStreamError = true, want false for nil")
+	}
+}
+
+func TestCloneRequestForH1RetryReplaysBodyAndForcesHTTP1(t *testing.T) {
+	req, err := http.NewRequestWithContext(context.Background(), "POST", "https://example.com", strings.NewReader("payload"))
+	if err != nil {
+		t.Fatalf("NewRequestWithContext: %v", err)
+	}
+	req.Header.Set("X-Test", "yes")
+
+	retry, err := cloneRequestForH1Retry(req)
+	if err != nil {
+		t.Fatalf("cloneRequestForH1Retry: %v", err)
+	}
+	if !forceHTTP1FromContext(retry.Context()) {
+		t.Error("retry request's context isn't forced onto http/1.1")
+	}
+	if retry.Header.Get("X-Test") != "yes" {
+		t.Error("retry request lost a header from the original")
+	}
+	body := make([]byte, 7)
+	if _, err := retry.Body.Read(body); err != nil {
+		t.Fatalf("reading replayed body: %v", err)
+	}
+	if string(body) != "payload" {
+		t.Errorf("replayed body = %q, want %q", body, "payload")
+	}
+}
+
+func TestDialForcedToHTTP1NegotiatesHTTP1OnlyALPN(t *testing.T) {
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	srv.EnableHTTP2 = true
+	// httptest.Server only sets NextProtos itself when it's nil, and
+	// EnableHTTP2 alone would narrow it to just "h2" - setting both here
+	// lets the server negotiate either, so this test can tell a forced h1
+	// dial (below) apart from an ordinary one that still lands on h2.
+	srv.TLS = &tls.Config{NextProtos: []string{"h2", "http/1.1"}}
+	srv.StartTLS()
+	defer srv.Close()
+
+	client := NewClientWithTls(&TlsConfig{SkipVerify: true})
+
+	host := strings.TrimPrefix(srv.URL, "https://")
+
+	forcedInfo := &TlsHandshakeInfo{}
+	forcedCtx := withTlsInfoHolder(withForceHTTP1(withTlsConfig(context.Background(), &TlsConfig{SkipVerify: true})), forcedInfo)
+	conn, err := client.dialTLS(forcedCtx, "tcp", host)
+	if err != nil {
+		t.Fatalf("dialTLS forced to h1: %v", err)
+	}
+	defer conn.Close()
+	if forcedInfo.NegotiatedALPN != "http/1.1" {
+		t.Errorf("forced dial negotiated %q, want http/1.1", forcedInfo.NegotiatedALPN)
+	}
+
+	info := &TlsHandshakeInfo{}
+	ctx2 := withTlsInfoHolder(withTlsConfig(context.Background(), &TlsConfig{SkipVerify: true}), info)
+	conn2, err := client.dialTLS(ctx2, "tcp", host)
+	if err != nil {
+		t.Fatalf("dialTLS unforced: %v", err)
+	}
+	defer conn2.Close()
+
+	if info.NegotiatedALPN != "h2" {
+		t.Errorf("unforced dial negotiated %q, want h2 (so forcing h1 above is a meaningful contrast)", info.NegotiatedALPN)
+	}
+}
@@ -0,0 +1,81 @@
+package httpclient
+
+import "sync"
+
+// defaultInitialWindowSize is HTTP/2's default SETTINGS_INITIAL_WINDOW_SIZE
+// and connection-level flow control window (RFC 9113 §6.9.2), used until a
+// server's SETTINGS frame (see ServerH2Settings.InitialWindowSize) says
+// otherwise.
+const defaultInitialWindowSize = 65535
+
+// flowControlWindow tracks a single HTTP/2 flow-control window - either the
+// connection-level one or a single stream's (RFC 9113 §6.9) - blocking
+// writers that would overdraw it until a WINDOW_UPDATE frame (see
+// Replenish) tops it back up. This is what lets a future RoundTrip
+// interleave DATA writes with incoming WINDOW_UPDATE frames instead of
+// blindly flushing an entire body and risking a RST_STREAM for violating
+// flow control.
+type flowControlWindow struct {
+	mu        sync.Mutex
+	cond      *sync.Cond
+	available int64
+}
+
+func newFlowControlWindow(initial uint32) *flowControlWindow {
+	w := &flowControlWindow{available: int64(initial)}
+	w.cond = sync.NewCond(&w.mu)
+	return w
+}
+
+// Reserve blocks until at least one byte of window is available, then
+// consumes and returns min(want, available bytes) - the caller sizes its
+// next DATA frame to the returned amount rather than want, since the window
+// may only cover a partial frame.
+func (w *flowControlWindow) Reserve(want int) int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for w.available <= 0 {
+		w.cond.Wait()
+	}
+	n := want
+	if int64(n) > w.available {
+		n = int(w.available)
+	}
+	w.available -= int64(n)
+	return n
+}
+
+// Replenish applies a WINDOW_UPDATE frame's increment, waking any writer
+// blocked in Reserve.
+func (w *flowControlWindow) Replenish(increment uint32) {
+	w.mu.Lock()
+	w.available += int64(increment)
+	w.mu.Unlock()
+	w.cond.Broadcast()
+}
+
+// writeDataRespectingFlowControl sends body as a sequence of chunks no
+// larger than maxFrameSize, reserving each chunk's bytes from window first -
+// blocking until WINDOW_UPDATE frames (via flowControlWindow.Replenish,
+// called concurrently as frames are read off the wire) make room, rather
+// than buffering and flushing the whole body at once. send is invoked with
+// each chunk once its reservation succeeds, and whether it's the final one.
+func writeDataRespectingFlowControl(body []byte, maxFrameSize int, window *flowControlWindow, send func(chunk []byte, last bool)) {
+	if maxFrameSize <= 0 {
+		maxFrameSize = defaultMaxFrameSize
+	}
+	if len(body) == 0 {
+		send(nil, true)
+		return
+	}
+
+	for len(body) > 0 {
+		want := len(body)
+		if want > maxFrameSize {
+			want = maxFrameSize
+		}
+		n := window.Reserve(want)
+		send(body[:n], n == len(body))
+		body = body[n:]
+	}
+}
@@ -0,0 +1,97 @@
+package httpclient
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestFlowControlWindowReserveBlocksUntilReplenished(t *testing.T) {
+	window := newFlowControlWindow(0)
+
+	done := make(chan int, 1)
+	go func() {
+		done <- window.Reserve(10)
+	}()
+
+	select {
+	case <-done:
+		t.Fatal("Reserve returned before any window was available")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	window.Replenish(10)
+
+	select {
+	case n := <-done:
+		if n != 10 {
+			t.Errorf("Reserve returned %d, want 10", n)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Reserve never returned after Replenish")
+	}
+}
+
+func TestFlowControlWindowReserveCapsAtAvailable(t *testing.T) {
+	window := newFlowControlWindow(5)
+	if n := window.Reserve(10); n != 5 {
+		t.Errorf("Reserve(10) with a 5-byte window = %d, want 5", n)
+	}
+}
+
+func TestWriteDataRespectingFlowControlUploadsBodyLargerThanDefaultWindow(t *testing.T) {
+	body := make([]byte, defaultInitialWindowSize+20000) // bigger than the 64KB default window
+	for i := range body {
+		body[i] = byte(i)
+	}
+
+	window := newFlowControlWindow(defaultInitialWindowSize)
+
+	var mu sync.Mutex
+	var sent []byte
+	var lastSeen bool
+	done := make(chan struct{})
+
+	go func() {
+		writeDataRespectingFlowControl(body, defaultMaxFrameSize, window, func(chunk []byte, last bool) {
+			mu.Lock()
+			sent = append(sent, chunk...)
+			lastSeen = last
+			mu.Unlock()
+		})
+		close(done)
+	}()
+
+	// The initial window only covers part of the body; the writer should
+	// block until this replenishes the rest.
+	time.Sleep(50 * time.Millisecond)
+	window.Replenish(uint32(len(body)))
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("writeDataRespectingFlowControl never finished after replenishing the window")
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if !lastSeen {
+		t.Error("never saw a final chunk")
+	}
+	if string(sent) != string(body) {
+		t.Error("sent bytes don't match the original body")
+	}
+}
+
+func TestWriteDataRespectingFlowControlEmptyBodySendsOneFinalChunk(t *testing.T) {
+	window := newFlowControlWindow(defaultInitialWindowSize)
+	var calls int
+	var lastSeen bool
+	writeDataRespectingFlowControl(nil, defaultMaxFrameSize, window, func(chunk []byte, last bool) {
+		calls++
+		lastSeen = last
+	})
+	if calls != 1 || !lastSeen {
+		t.Errorf("calls = %d, lastSeen = %v, want a single final call for an empty body", calls, lastSeen)
+	}
+}
@@ -0,0 +1,247 @@
+package httpclient
+
+import (
+	"encoding/binary"
+	"errors"
+
+	"httpcall/internal/tlsfp"
+)
+
+// defaultMaxFrameSize is HTTP/2's minimum-mandated SETTINGS_MAX_FRAME_SIZE
+// (RFC 9113 §6.5.2), used when a peer hasn't advertised a larger one.
+const defaultMaxFrameSize = 16384
+
+// frameTypePriority is the HTTP/2 PRIORITY frame type (RFC 9113 §6.3).
+const frameTypePriority = 0x2
+
+// encodePriorityFrame serializes f into a full RFC 9113 §6.3 PRIORITY
+// frame: a 9-byte frame header (length=5, type=PRIORITY, no flags, f's own
+// stream ID) followed by the 5-byte payload (exclusive bit + 31-bit
+// dependent stream ID, then the 1-byte weight).
+func encodePriorityFrame(f tlsfp.PriorityFrame) []byte {
+	frame := make([]byte, 9+5)
+	frame[0], frame[1], frame[2] = 0, 0, 5 // 24-bit length, always 5 for PRIORITY
+	frame[3] = frameTypePriority
+	frame[4] = 0 // flags, unused by PRIORITY
+	binary.BigEndian.PutUint32(frame[5:9], f.StreamID)
+
+	dependsOn := f.DependsOn
+	if f.Exclusive {
+		dependsOn |= 1 << 31
+	}
+	binary.BigEndian.PutUint32(frame[9:13], dependsOn)
+	frame[13] = f.Weight
+	return frame
+}
+
+// encodePriorityFrames serializes frames in order, for writing before the
+// HEADERS frame (see CustomH2Transport.PriorityFrameBytes).
+func encodePriorityFrames(frames []tlsfp.PriorityFrame) []byte {
+	var out []byte
+	for _, f := range frames {
+		out = append(out, encodePriorityFrame(f)...)
+	}
+	return out
+}
+
+// frameTypeSettings is the HTTP/2 SETTINGS frame type (RFC 9113 §6.5).
+const frameTypeSettings = 0x4
+
+// SETTINGS parameter identifiers, RFC 9113 §6.5.2.
+const (
+	settingHeaderTableSize      = 0x1
+	settingEnablePush           = 0x2
+	settingMaxConcurrentStreams = 0x3
+	settingInitialWindowSize    = 0x4
+	settingMaxFrameSizeID       = 0x5
+	settingMaxHeaderListSize    = 0x6
+)
+
+// ServerH2Settings is a peer's SETTINGS frame decoded into its known
+// parameters (RFC 9113 §6.5.2). Each field is a pointer so a setting the
+// peer never sent is distinguishable from one explicitly sent as zero.
+type ServerH2Settings struct {
+	HeaderTableSize      *uint32 `json:"headerTableSize,omitempty"`
+	EnablePush           *bool   `json:"enablePush,omitempty"`
+	MaxConcurrentStreams *uint32 `json:"maxConcurrentStreams,omitempty"`
+	InitialWindowSize    *uint32 `json:"initialWindowSize,omitempty"`
+	MaxFrameSize         *uint32 `json:"maxFrameSize,omitempty"`
+	MaxHeaderListSize    *uint32 `json:"maxHeaderListSize,omitempty"`
+}
+
+// decodeSettingsFrame parses payload - a SETTINGS frame's body, i.e. not
+// including its 9-byte frame header - into a ServerH2Settings. Per RFC 9113
+// §6.5.2, unrecognized identifiers are ignored rather than rejected, since a
+// future extension is free to define new ones. This is the read-side
+// counterpart CustomH2Transport's future RoundTrip would call on a server's
+// SETTINGS frame once it actually reads frames off the wire; nothing in this
+// tree does that yet, so it isn't reachable from ResponseData.
+func decodeSettingsFrame(payload []byte) (ServerH2Settings, error) {
+	if len(payload)%6 != 0 {
+		return ServerH2Settings{}, errors.New("h2frames: SETTINGS payload length not a multiple of 6")
+	}
+
+	var s ServerH2Settings
+	for i := 0; i+6 <= len(payload); i += 6 {
+		id := binary.BigEndian.Uint16(payload[i : i+2])
+		value := binary.BigEndian.Uint32(payload[i+2 : i+6])
+		switch id {
+		case settingHeaderTableSize:
+			s.HeaderTableSize = &value
+		case settingEnablePush:
+			enabled := value != 0
+			s.EnablePush = &enabled
+		case settingMaxConcurrentStreams:
+			s.MaxConcurrentStreams = &value
+		case settingInitialWindowSize:
+			s.InitialWindowSize = &value
+		case settingMaxFrameSizeID:
+			s.MaxFrameSize = &value
+		case settingMaxHeaderListSize:
+			s.MaxHeaderListSize = &value
+		}
+	}
+	return s, nil
+}
+
+// frameTypeData is the HTTP/2 DATA frame type (RFC 9113 §6.1).
+const frameTypeData = 0x0
+
+// DataFrame is one DATA frame's worth of a request body chunk.
+type DataFrame struct {
+	Fragment  []byte
+	EndStream bool
+}
+
+// splitDataFrames breaks body into DATA frame chunks no larger than
+// maxFrameSize (RFC 9113 §6.1) - the same oversized-split logic
+// splitHeaderBlock applies to header blocks. Callers should pass the
+// server's advertised SETTINGS_MAX_FRAME_SIZE (see
+// CustomH2Transport.dataFrameSize) rather than hardcoding a size, so large
+// uploads take as few frames as the peer allows. maxFrameSize <= 0 falls
+// back to defaultMaxFrameSize.
+func splitDataFrames(body []byte, maxFrameSize int) []DataFrame {
+	if maxFrameSize <= 0 {
+		maxFrameSize = defaultMaxFrameSize
+	}
+	if len(body) == 0 {
+		return []DataFrame{{EndStream: true}}
+	}
+
+	var frames []DataFrame
+	for len(body) > 0 {
+		n := len(body)
+		if n > maxFrameSize {
+			n = maxFrameSize
+		}
+		frames = append(frames, DataFrame{Fragment: body[:n], EndStream: n == len(body)})
+		body = body[n:]
+	}
+	return frames
+}
+
+// HeaderBlockFrame is one HEADERS or CONTINUATION frame's worth of a header
+// block: a fragment of the encoded block, plus whether this fragment ends
+// the block (END_HEADERS).
+type HeaderBlockFrame struct {
+	Fragment   []byte
+	EndHeaders bool
+}
+
+// encodeHeaderBlock serializes fields into a single contiguous header block
+// using a simple length-prefixed encoding. HttpCall doesn't link an HPACK
+// encoder (the only one available is in golang.org/x/net, an external
+// dependency this build can't fetch), so this is an internal stand-in: it's
+// enough to validate the HEADERS/CONTINUATION splitting and reassembly
+// logic below, which is what actually needs testing here.
+func encodeHeaderBlock(fields []HeaderField) []byte {
+	var block []byte
+	for _, f := range fields {
+		block = appendLengthPrefixed(block, f.Name)
+		block = appendLengthPrefixed(block, f.Value)
+	}
+	return block
+}
+
+// decodeHeaderBlock reverses encodeHeaderBlock.
+func decodeHeaderBlock(block []byte) ([]HeaderField, error) {
+	var fields []HeaderField
+	for len(block) > 0 {
+		name, rest, err := readLengthPrefixed(block)
+		if err != nil {
+			return nil, err
+		}
+		value, rest2, err := readLengthPrefixed(rest)
+		if err != nil {
+			return nil, err
+		}
+		fields = append(fields, HeaderField{Name: name, Value: value})
+		block = rest2
+	}
+	return fields, nil
+}
+
+func appendLengthPrefixed(buf []byte, s string) []byte {
+	var lenBytes [4]byte
+	binary.BigEndian.PutUint32(lenBytes[:], uint32(len(s)))
+	buf = append(buf, lenBytes[:]...)
+	return append(buf, s...)
+}
+
+func readLengthPrefixed(buf []byte) (string, []byte, error) {
+	if len(buf) < 4 {
+		return "", nil, errors.New("h2frames: truncated length prefix")
+	}
+	n := binary.BigEndian.Uint32(buf[:4])
+	buf = buf[4:]
+	if uint32(len(buf)) < n {
+		return "", nil, errors.New("h2frames: truncated field")
+	}
+	return string(buf[:n]), buf[n:], nil
+}
+
+// splitHeaderBlock breaks an encoded header block into a HEADERS frame
+// fragment followed by as many CONTINUATION fragments as needed so none
+// exceeds maxFrameSize, matching how a real HTTP/2 sender must split an
+// oversized block (RFC 9113 §6.2) instead of failing outright. maxFrameSize
+// <= 0 falls back to defaultMaxFrameSize.
+func splitHeaderBlock(block []byte, maxFrameSize int) []HeaderBlockFrame {
+	if maxFrameSize <= 0 {
+		maxFrameSize = defaultMaxFrameSize
+	}
+	if len(block) == 0 {
+		return []HeaderBlockFrame{{EndHeaders: true}}
+	}
+
+	var frames []HeaderBlockFrame
+	for len(block) > 0 {
+		n := len(block)
+		if n > maxFrameSize {
+			n = maxFrameSize
+		}
+		frames = append(frames, HeaderBlockFrame{Fragment: block[:n], EndHeaders: n == len(block)})
+		block = block[n:]
+	}
+	return frames
+}
+
+// reassembleHeaderBlock is the receive-side counterpart to splitHeaderBlock:
+// it concatenates a HEADERS frame and its CONTINUATION frames back into one
+// block, validating that END_HEADERS was set on the last fragment only.
+func reassembleHeaderBlock(frames []HeaderBlockFrame) ([]byte, error) {
+	if len(frames) == 0 {
+		return nil, errors.New("h2frames: no header frames to reassemble")
+	}
+	var block []byte
+	for i, f := range frames {
+		isLast := i == len(frames)-1
+		if f.EndHeaders != isLast {
+			if f.EndHeaders {
+				return nil, errors.New("h2frames: END_HEADERS set before the final CONTINUATION frame")
+			}
+			return nil, errors.New("h2frames: missing END_HEADERS on the final frame")
+		}
+		block = append(block, f.Fragment...)
+	}
+	return block, nil
+}
@@ -0,0 +1,219 @@
+package httpclient
+
+import (
+	"encoding/binary"
+	"strings"
+	"testing"
+
+	"httpcall/internal/tlsfp"
+)
+
+func TestSplitHeaderBlockSpansContinuationFrames(t *testing.T) {
+	fields := []HeaderField{
+		{Name: ":method", Value: "GET"},
+		{Name: ":authority", Value: "example.com"},
+		{Name: "cookie", Value: strings.Repeat("a", 50000)}, // forces multiple frames
+	}
+	block := encodeHeaderBlock(fields)
+
+	frames := splitHeaderBlock(block, defaultMaxFrameSize)
+	if len(frames) < 2 {
+		t.Fatalf("got %d frames, want at least 2 for a %d-byte block", len(frames), len(block))
+	}
+	for i, f := range frames {
+		isLast := i == len(frames)-1
+		if f.EndHeaders != isLast {
+			t.Errorf("frame %d EndHeaders = %v, want %v", i, f.EndHeaders, isLast)
+		}
+		if !isLast && len(f.Fragment) != defaultMaxFrameSize {
+			t.Errorf("frame %d has %d bytes, want exactly %d (non-final)", i, len(f.Fragment), defaultMaxFrameSize)
+		}
+	}
+
+	reassembled, err := reassembleHeaderBlock(frames)
+	if err != nil {
+		t.Fatalf("reassembleHeaderBlock: %v", err)
+	}
+	got, err := decodeHeaderBlock(reassembled)
+	if err != nil {
+		t.Fatalf("decodeHeaderBlock: %v", err)
+	}
+	if len(got) != len(fields) {
+		t.Fatalf("got %d fields, want %d", len(got), len(fields))
+	}
+	for i := range fields {
+		if got[i] != fields[i] {
+			t.Errorf("field %d = %+v, want %+v", i, got[i], fields[i])
+		}
+	}
+}
+
+func TestReassembleHeaderBlockRejectsEarlyEndHeaders(t *testing.T) {
+	frames := []HeaderBlockFrame{
+		{Fragment: []byte("a"), EndHeaders: true},
+		{Fragment: []byte("b"), EndHeaders: true},
+	}
+	if _, err := reassembleHeaderBlock(frames); err == nil {
+		t.Fatal("expected an error for END_HEADERS set before the final frame")
+	}
+}
+
+func TestSplitHeaderBlockSmallBlockIsOneFrame(t *testing.T) {
+	block := encodeHeaderBlock([]HeaderField{{Name: ":method", Value: "GET"}})
+	frames := splitHeaderBlock(block, defaultMaxFrameSize)
+	if len(frames) != 1 || !frames[0].EndHeaders {
+		t.Fatalf("got %+v, want a single EndHeaders frame", frames)
+	}
+}
+
+func TestEncodePriorityFrameHeaderFields(t *testing.T) {
+	frame := encodePriorityFrame(tlsfp.PriorityFrame{StreamID: 3, Exclusive: false, DependsOn: 0, Weight: 201})
+	if len(frame) != 14 {
+		t.Fatalf("frame length = %d, want 14 (9-byte header + 5-byte payload)", len(frame))
+	}
+	length := uint32(frame[0])<<16 | uint32(frame[1])<<8 | uint32(frame[2])
+	if length != 5 {
+		t.Errorf("frame length field = %d, want 5", length)
+	}
+	if frame[3] != frameTypePriority {
+		t.Errorf("frame type = %#x, want %#x (PRIORITY)", frame[3], frameTypePriority)
+	}
+	if streamID := binary.BigEndian.Uint32(frame[5:9]); streamID != 3 {
+		t.Errorf("frame stream ID = %d, want 3", streamID)
+	}
+	dependsOn := binary.BigEndian.Uint32(frame[9:13])
+	if dependsOn&(1<<31) != 0 {
+		t.Error("exclusive bit set, want clear")
+	}
+	if frame[13] != 201 {
+		t.Errorf("weight = %d, want 201", frame[13])
+	}
+}
+
+func TestEncodePriorityFrameSetsExclusiveBit(t *testing.T) {
+	frame := encodePriorityFrame(tlsfp.PriorityFrame{StreamID: 3, Exclusive: true, DependsOn: 5, Weight: 1})
+	dependsOn := binary.BigEndian.Uint32(frame[9:13])
+	if dependsOn&(1<<31) == 0 {
+		t.Error("exclusive bit clear, want set")
+	}
+	if dependsOn&^(1<<31) != 5 {
+		t.Errorf("dependent stream ID = %d, want 5", dependsOn&^(1<<31))
+	}
+}
+
+func TestEncodePriorityFramesConcatenatesInOrder(t *testing.T) {
+	frames := []tlsfp.PriorityFrame{
+		{StreamID: 3, DependsOn: 0, Weight: 201},
+		{StreamID: 5, DependsOn: 0, Weight: 101},
+	}
+	out := encodePriorityFrames(frames)
+	if len(out) != 28 {
+		t.Fatalf("got %d bytes, want 28 (two 14-byte frames)", len(out))
+	}
+	if firstStreamID := binary.BigEndian.Uint32(out[5:9]); firstStreamID != 3 {
+		t.Errorf("first frame stream ID = %d, want 3", firstStreamID)
+	}
+	if secondStreamID := binary.BigEndian.Uint32(out[19:23]); secondStreamID != 5 {
+		t.Errorf("second frame stream ID = %d, want 5", secondStreamID)
+	}
+}
+
+func encodeSettingEntry(id uint16, value uint32) []byte {
+	entry := make([]byte, 6)
+	binary.BigEndian.PutUint16(entry[0:2], id)
+	binary.BigEndian.PutUint32(entry[2:6], value)
+	return entry
+}
+
+func TestDecodeSettingsFrameParsesKnownIdentifiers(t *testing.T) {
+	var payload []byte
+	payload = append(payload, encodeSettingEntry(settingHeaderTableSize, 4096)...)
+	payload = append(payload, encodeSettingEntry(settingEnablePush, 0)...)
+	payload = append(payload, encodeSettingEntry(settingMaxConcurrentStreams, 100)...)
+	payload = append(payload, encodeSettingEntry(settingInitialWindowSize, 65535)...)
+	payload = append(payload, encodeSettingEntry(settingMaxFrameSizeID, 16384)...)
+	payload = append(payload, encodeSettingEntry(settingMaxHeaderListSize, 8192)...)
+
+	s, err := decodeSettingsFrame(payload)
+	if err != nil {
+		t.Fatalf("decodeSettingsFrame: %v", err)
+	}
+	if s.HeaderTableSize == nil || *s.HeaderTableSize != 4096 {
+		t.Errorf("HeaderTableSize = %v, want 4096", s.HeaderTableSize)
+	}
+	if s.EnablePush == nil || *s.EnablePush != false {
+		t.Errorf("EnablePush = %v, want false", s.EnablePush)
+	}
+	if s.MaxConcurrentStreams == nil || *s.MaxConcurrentStreams != 100 {
+		t.Errorf("MaxConcurrentStreams = %v, want 100", s.MaxConcurrentStreams)
+	}
+	if s.InitialWindowSize == nil || *s.InitialWindowSize != 65535 {
+		t.Errorf("InitialWindowSize = %v, want 65535", s.InitialWindowSize)
+	}
+	if s.MaxFrameSize == nil || *s.MaxFrameSize != 16384 {
+		t.Errorf("MaxFrameSize = %v, want 16384", s.MaxFrameSize)
+	}
+	if s.MaxHeaderListSize == nil || *s.MaxHeaderListSize != 8192 {
+		t.Errorf("MaxHeaderListSize = %v, want 8192", s.MaxHeaderListSize)
+	}
+}
+
+func TestDecodeSettingsFrameIgnoresUnknownIdentifiers(t *testing.T) {
+	payload := encodeSettingEntry(0x9, 42)
+	s, err := decodeSettingsFrame(payload)
+	if err != nil {
+		t.Fatalf("decodeSettingsFrame: %v", err)
+	}
+	if s.HeaderTableSize != nil || s.MaxFrameSize != nil {
+		t.Errorf("got %+v, want all-nil ServerH2Settings for an unknown identifier", s)
+	}
+}
+
+func TestDecodeSettingsFrameRejectsTruncatedPayload(t *testing.T) {
+	if _, err := decodeSettingsFrame([]byte{0x00, 0x01, 0x00}); err == nil {
+		t.Fatal("want error for a payload not a multiple of 6 bytes")
+	}
+}
+
+func TestSplitDataFramesSpansMultipleFrames(t *testing.T) {
+	body := make([]byte, 30)
+	for i := range body {
+		body[i] = byte(i)
+	}
+
+	frames := splitDataFrames(body, 10)
+	if len(frames) != 3 {
+		t.Fatalf("got %d frames, want 3", len(frames))
+	}
+	for i, f := range frames {
+		wantEnd := i == len(frames)-1
+		if f.EndStream != wantEnd {
+			t.Errorf("frame %d EndStream = %v, want %v", i, f.EndStream, wantEnd)
+		}
+	}
+	var reassembled []byte
+	for _, f := range frames {
+		reassembled = append(reassembled, f.Fragment...)
+	}
+	if string(reassembled) != string(body) {
+		t.Error("reassembled fragments don't match the original body")
+	}
+}
+
+func TestSplitDataFramesEmptyBodyIsOneEndStreamFrame(t *testing.T) {
+	frames := splitDataFrames(nil, 10)
+	if len(frames) != 1 || !frames[0].EndStream || len(frames[0].Fragment) != 0 {
+		t.Errorf("got %+v, want a single empty EndStream frame", frames)
+	}
+}
+
+func TestSplitDataFramesDefaultsMaxFrameSize(t *testing.T) {
+	body := make([]byte, defaultMaxFrameSize+1)
+	frames := splitDataFrames(body, 0)
+	if len(frames) != 2 {
+		t.Fatalf("got %d frames, want 2 when maxFrameSize <= 0 falls back to defaultMaxFrameSize", len(frames))
+	}
+	if len(frames[0].Fragment) != defaultMaxFrameSize {
+		t.Errorf("first frame = %d bytes, want %d", len(frames[0].Fragment), defaultMaxFrameSize)
+	}
+}
@@ -0,0 +1,68 @@
+package httpclient
+
+import "io"
+
+// FlushStrategy controls how CustomH2Transport batches its outbound frames.
+type FlushStrategy int
+
+const (
+	// FlushFullCoalesce buffers every frame (SETTINGS, WINDOW_UPDATE,
+	// HEADERS, and all DATA) into one write. Best fingerprint fidelity,
+	// since everything can land in one TCP segment, but it forces the whole
+	// request body into memory first.
+	FlushFullCoalesce FlushStrategy = iota
+	// FlushHeadersOnly coalesces SETTINGS/WINDOW_UPDATE/HEADERS into one
+	// write, then streams DATA frames directly as they're produced. Trades
+	// a little fidelity for large-upload memory use.
+	FlushHeadersOnly
+)
+
+// frameWriter buffers outbound frames per FlushStrategy before writing them
+// to w, the underlying connection.
+type frameWriter struct {
+	w          io.Writer
+	strategy   FlushStrategy
+	buf        []byte
+	controlOut bool // true once the control (non-DATA) frames have been flushed
+}
+
+func newFrameWriter(w io.Writer, strategy FlushStrategy) *frameWriter {
+	return &frameWriter{w: w, strategy: strategy}
+}
+
+// writeControl buffers a control frame (SETTINGS, WINDOW_UPDATE, HEADERS).
+// Control frames are always coalesced regardless of strategy.
+func (fw *frameWriter) writeControl(frame []byte) {
+	fw.buf = append(fw.buf, frame...)
+}
+
+// writeData appends (full coalesce) or streams (headers-only) a DATA frame.
+func (fw *frameWriter) writeData(frame []byte) (int, error) {
+	if fw.strategy == FlushHeadersOnly {
+		if err := fw.flushControl(); err != nil {
+			return 0, err
+		}
+		return fw.w.Write(frame)
+	}
+	fw.buf = append(fw.buf, frame...)
+	return len(frame), nil
+}
+
+// flushControl writes and clears whatever control bytes are buffered.
+func (fw *frameWriter) flushControl() error {
+	if fw.controlOut || len(fw.buf) == 0 {
+		return nil
+	}
+	fw.controlOut = true
+	_, err := fw.w.Write(fw.buf)
+	fw.buf = nil
+	return err
+}
+
+// finish flushes anything still buffered: under FlushFullCoalesce that's
+// the entire request (control + data) in one write; under FlushHeadersOnly
+// the control frames already went out, so this is a no-op unless no DATA
+// frame was ever written.
+func (fw *frameWriter) finish() error {
+	return fw.flushControl()
+}
@@ -0,0 +1,76 @@
+package httpclient
+
+import "testing"
+
+// recordingWriter records the byte length of each individual Write call so
+// tests can tell whether writes were coalesced into one or streamed apart.
+type recordingWriter struct {
+	writes [][]byte
+}
+
+func (r *recordingWriter) Write(p []byte) (int, error) {
+	cp := append([]byte(nil), p...)
+	r.writes = append(r.writes, cp)
+	return len(p), nil
+}
+
+func TestFrameWriterFullCoalesceSendsOneWrite(t *testing.T) {
+	rw := &recordingWriter{}
+	fw := newFrameWriter(rw, FlushFullCoalesce)
+
+	fw.writeControl([]byte("SETTINGS"))
+	fw.writeControl([]byte("HEADERS"))
+	if _, err := fw.writeData([]byte("DATA1")); err != nil {
+		t.Fatalf("writeData: %v", err)
+	}
+	if _, err := fw.writeData([]byte("DATA2")); err != nil {
+		t.Fatalf("writeData: %v", err)
+	}
+	if len(rw.writes) != 0 {
+		t.Fatalf("expected no writes before finish, got %d", len(rw.writes))
+	}
+
+	if err := fw.finish(); err != nil {
+		t.Fatalf("finish: %v", err)
+	}
+	if len(rw.writes) != 1 {
+		t.Fatalf("got %d writes, want 1 (everything coalesced)", len(rw.writes))
+	}
+	if got, want := string(rw.writes[0]), "SETTINGSHEADERSDATA1DATA2"; got != want {
+		t.Errorf("coalesced write = %q, want %q", got, want)
+	}
+}
+
+func TestFrameWriterHeadersOnlyStreamsData(t *testing.T) {
+	rw := &recordingWriter{}
+	fw := newFrameWriter(rw, FlushHeadersOnly)
+
+	fw.writeControl([]byte("SETTINGS"))
+	fw.writeControl([]byte("HEADERS"))
+	if len(rw.writes) != 0 {
+		t.Fatalf("expected no writes before the first DATA frame, got %d", len(rw.writes))
+	}
+
+	if _, err := fw.writeData([]byte("DATA1")); err != nil {
+		t.Fatalf("writeData: %v", err)
+	}
+	if _, err := fw.writeData([]byte("DATA2")); err != nil {
+		t.Fatalf("writeData: %v", err)
+	}
+	if err := fw.finish(); err != nil {
+		t.Fatalf("finish: %v", err)
+	}
+
+	if len(rw.writes) != 3 {
+		t.Fatalf("got %d writes, want 3 (1 header write + 2 streamed data writes)", len(rw.writes))
+	}
+	if got, want := string(rw.writes[0]), "SETTINGSHEADERS"; got != want {
+		t.Errorf("first write = %q, want %q", got, want)
+	}
+	if got, want := string(rw.writes[1]), "DATA1"; got != want {
+		t.Errorf("second write = %q, want %q", got, want)
+	}
+	if got, want := string(rw.writes[2]), "DATA2"; got != want {
+		t.Errorf("third write = %q, want %q", got, want)
+	}
+}
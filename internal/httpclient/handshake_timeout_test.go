@@ -0,0 +1,44 @@
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+)
+
+func TestDialTLSTimesOutOnStalledHandshake(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen: %v", err)
+	}
+	defer ln.Close()
+
+	accepted := make(chan struct{})
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		close(accepted)
+		time.Sleep(2 * time.Second) // never speak TLS back; hold the conn open past the test's timeout
+		conn.Close()
+	}()
+
+	client := NewClient()
+	ctx := withTlsConfig(context.Background(), &TlsConfig{HandshakeTimeout: 100 * time.Millisecond})
+
+	_, err = client.dialTLS(ctx, "tcp", ln.Addr().String())
+	if err == nil {
+		t.Fatal("expected a handshake timeout error, got nil")
+	}
+	var tlsErr *TlsHandshakeError
+	if !errors.As(err, &tlsErr) {
+		t.Fatalf("error %v is not a *TlsHandshakeError", err)
+	}
+	if tlsErr.Category != TlsErrorHandshakeTimeout {
+		t.Errorf("Category = %q, want %q", tlsErr.Category, TlsErrorHandshakeTimeout)
+	}
+	<-accepted
+}
@@ -0,0 +1,55 @@
+package httpclient
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+func TestEncodeHeadersStripsHopByHopHeaders(t *testing.T) {
+	req := &http.Request{
+		Method: "GET",
+		URL:    &url.URL{Scheme: "https", Host: "example.com", Path: "/"},
+		Header: http.Header{
+			"Connection":        []string{"keep-alive"},
+			"Keep-Alive":        []string{"timeout=5"},
+			"Transfer-Encoding": []string{"chunked"},
+			"Upgrade":           []string{"websocket"},
+			"Accept":            []string{"application/json"},
+		},
+	}
+
+	fields, stripped := encodeHeaders(req)
+
+	for _, name := range []string{"connection", "keep-alive", "transfer-encoding", "upgrade"} {
+		if _, ok := findHeader(fields, name); ok {
+			t.Errorf("%q should have been stripped from the h2 header list", name)
+		}
+	}
+	if _, ok := findHeader(fields, "accept"); !ok {
+		t.Error("accept should not have been stripped")
+	}
+	if len(stripped) != 4 {
+		t.Errorf("stripped = %v, want 4 hop-by-hop header names", stripped)
+	}
+}
+
+func TestResolveHeaderFieldsRecordsStrippedHopByHopHeaders(t *testing.T) {
+	req := &http.Request{
+		Method: "GET",
+		URL:    &url.URL{Scheme: "https", Host: "example.com", Path: "/"},
+		Header: http.Header{"Connection": []string{"close"}},
+	}
+
+	transport := NewCustomH2Transport(nil)
+	fields, err := transport.resolveHeaderFields(req)
+	if err != nil {
+		t.Fatalf("resolveHeaderFields: %v", err)
+	}
+	if _, ok := findHeader(fields, "connection"); ok {
+		t.Error("Connection header leaked through to the h2 field list")
+	}
+	if len(transport.StrippedHopByHop) != 1 || transport.StrippedHopByHop[0] != "Connection" {
+		t.Errorf("StrippedHopByHop = %v, want [\"Connection\"]", transport.StrippedHopByHop)
+	}
+}
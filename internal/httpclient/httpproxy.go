@@ -0,0 +1,159 @@
+package httpclient
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// defaultProxyDialTimeout bounds HTTPProxyDialer.DialContext end to end
+// (connecting plus waiting for the CONNECT response) when DialTimeout is
+// left at zero.
+const defaultProxyDialTimeout = 15 * time.Second
+
+// HTTPProxyDialer dials a proxy in plaintext and issues a CONNECT request -
+// the plaintext counterpart to HTTPSProxyDialer, which does the same thing
+// over a TLS-wrapped connection to the proxy.
+type HTTPProxyDialer struct {
+	ProxyAddr string
+	Username  string
+	Password  string
+
+	// DialTimeout bounds connecting to the proxy and waiting for its
+	// CONNECT response, so an unresponsive or black-holed proxy fails
+	// fast instead of hanging the request indefinitely. Zero means
+	// defaultProxyDialTimeout (15s). A shorter context deadline still
+	// takes precedence.
+	DialTimeout time.Duration
+}
+
+func (d *HTTPProxyDialer) dialTimeout() time.Duration {
+	if d.DialTimeout > 0 {
+		return d.DialTimeout
+	}
+	return defaultProxyDialTimeout
+}
+
+// DialContext connects to d.ProxyAddr, sends a CONNECT request for addr
+// (with Proxy-Authorization if credentials are set), and returns the
+// tunnel as a net.Conn once the proxy answers 200. The whole exchange -
+// dial plus CONNECT response, including a digest auth retry if the proxy
+// demands one - is bounded by d.dialTimeout() or by ctx's own deadline,
+// whichever comes first.
+//
+// The first attempt authenticates with Basic (if credentials are set) or
+// not at all. If the proxy answers 407 with a Digest challenge instead of
+// accepting that, DialContext parses the challenge, computes a digest
+// response, and retries once over a fresh connection with
+// Proxy-Authorization: Digest.
+func (d *HTTPProxyDialer) DialContext(ctx context.Context, network, addr string) (net.Conn, error) {
+	deadline := time.Now().Add(d.dialTimeout())
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		deadline = ctxDeadline
+	}
+	dialCtx, cancel := context.WithDeadline(ctx, deadline)
+	defer cancel()
+
+	conn, resp, err := d.connect(dialCtx, deadline, addr, "")
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusProxyAuthRequired {
+		conn.Close()
+		digestAuth, derr := d.digestAuthorization(resp, addr)
+		if derr != nil {
+			return nil, fmt.Errorf("http proxy: CONNECT to %s failed: %s: %w", addr, resp.Status, derr)
+		}
+		conn, resp, err = d.connect(dialCtx, deadline, addr, digestAuth)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		conn.Close()
+		return nil, fmt.Errorf("http proxy: CONNECT to %s failed: %s", addr, resp.Status)
+	}
+
+	// Clear the deadline now that the CONNECT handshake succeeded - the
+	// tunnel is handed off for the actual request, which has its own
+	// timeout handling.
+	if err := conn.SetDeadline(time.Time{}); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return conn, nil
+}
+
+// connect dials a fresh connection to the proxy and issues one CONNECT
+// request for addr, setting Proxy-Authorization to proxyAuth if non-empty,
+// or a Basic header from d.Username/d.Password otherwise. It returns the
+// tunnel (wrapped to replay any bytes ReadResponse over-buffered) and the
+// proxy's response so the caller can inspect its status before committing
+// to it.
+func (d *HTTPProxyDialer) connect(ctx context.Context, deadline time.Time, addr, proxyAuth string) (net.Conn, *http.Response, error) {
+	conn, err := (&net.Dialer{}).DialContext(ctx, "tcp", d.ProxyAddr)
+	if err != nil {
+		return nil, nil, fmt.Errorf("http proxy: dialing %s: %w", d.ProxyAddr, err)
+	}
+	if err := conn.SetDeadline(deadline); err != nil {
+		conn.Close()
+		return nil, nil, err
+	}
+
+	req := &http.Request{
+		Method: http.MethodConnect,
+		URL:    &url.URL{Opaque: addr},
+		Host:   addr,
+		Header: make(http.Header),
+	}
+	switch {
+	case proxyAuth != "":
+		req.Header.Set("Proxy-Authorization", proxyAuth)
+	case d.Username != "" || d.Password != "":
+		creds := base64.StdEncoding.EncodeToString([]byte(d.Username + ":" + d.Password))
+		req.Header.Set("Proxy-Authorization", "Basic "+creds)
+	}
+
+	if err := req.Write(conn); err != nil {
+		conn.Close()
+		return nil, nil, fmt.Errorf("http proxy: sending CONNECT: %w", err)
+	}
+
+	br := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(br, req)
+	if err != nil {
+		conn.Close()
+		return nil, nil, fmt.Errorf("http proxy: reading CONNECT response: %w", err)
+	}
+	resp.Body.Close()
+
+	if br.Buffered() > 0 {
+		return &bufferedConn{Conn: conn, r: br}, resp, nil
+	}
+	return conn, resp, nil
+}
+
+// digestAuthorization builds a Proxy-Authorization: Digest header answering
+// resp's Proxy-Authenticate challenge. It errors if the proxy didn't offer
+// Digest or no credentials are configured to answer it with.
+func (d *HTTPProxyDialer) digestAuthorization(resp *http.Response, addr string) (string, error) {
+	if d.Username == "" && d.Password == "" {
+		return "", fmt.Errorf("proxy requires auth but no credentials are configured")
+	}
+	challenge, err := parseDigestChallenge(resp.Header.Get("Proxy-Authenticate"))
+	if err != nil {
+		return "", err
+	}
+	cnonce, err := generateCnonce()
+	if err != nil {
+		return "", err
+	}
+	return buildDigestResponse(d.Username, d.Password, http.MethodConnect, addr, challenge, "00000001", cnonce), nil
+}
@@ -0,0 +1,289 @@
+package httpclient
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeConnectProxy runs a one-shot plaintext CONNECT proxy, mirroring
+// fakeTLSConnectProxy but without the TLS wrapper.
+func fakeConnectProxy(t *testing.T, upstreamAddr string) string {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	t.Cleanup(func() { ln.Close() })
+
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		br := bufio.NewReader(conn)
+		req, err := http.ReadRequest(br)
+		if err != nil || req.Method != http.MethodConnect {
+			return
+		}
+
+		upstream, err := net.Dial("tcp", upstreamAddr)
+		if err != nil {
+			conn.Write([]byte("HTTP/1.1 502 Bad Gateway\r\n\r\n"))
+			return
+		}
+		defer upstream.Close()
+
+		conn.Write([]byte("HTTP/1.1 200 Connection Established\r\n\r\n"))
+
+		done := make(chan struct{}, 2)
+		go func() { copyUntilDone(upstream, br, done) }()
+		go func() { copyUntilDone(conn, upstream, done) }()
+		<-done
+	}()
+
+	return ln.Addr().String()
+}
+
+func copyUntilDone(dst net.Conn, src interface{ Read([]byte) (int, error) }, done chan struct{}) {
+	buf := make([]byte, 4096)
+	for {
+		n, err := src.Read(buf)
+		if n > 0 {
+			if _, werr := dst.Write(buf[:n]); werr != nil {
+				break
+			}
+		}
+		if err != nil {
+			break
+		}
+	}
+	done <- struct{}{}
+}
+
+func TestHTTPProxyDialerTunnelsThroughConnectProxy(t *testing.T) {
+	echoLn, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	defer echoLn.Close()
+	go func() {
+		conn, err := echoLn.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		buf := make([]byte, 5)
+		if _, err := readFull(conn, buf); err != nil {
+			return
+		}
+		conn.Write(buf)
+	}()
+
+	proxyAddr := fakeConnectProxy(t, echoLn.Addr().String())
+
+	dialer := &HTTPProxyDialer{ProxyAddr: proxyAddr}
+	conn, err := dialer.DialContext(context.Background(), "tcp", echoLn.Addr().String())
+	if err != nil {
+		t.Fatalf("DialContext: %v", err)
+	}
+	defer conn.Close()
+
+	if _, err := conn.Write([]byte("hello")); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	buf := make([]byte, 5)
+	if _, err := readFull(conn, buf); err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	if string(buf) != "hello" {
+		t.Errorf("echoed %q, want %q", buf, "hello")
+	}
+}
+
+func TestHTTPProxyDialerFailsFastOnUnresponsiveProxy(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	defer ln.Close()
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		// Accept the connection but never reply to the CONNECT request -
+		// the black-holed-proxy case DialTimeout exists for.
+		defer conn.Close()
+		time.Sleep(time.Second)
+	}()
+
+	dialer := &HTTPProxyDialer{ProxyAddr: ln.Addr().String(), DialTimeout: 50 * time.Millisecond}
+	start := time.Now()
+	_, err = dialer.DialContext(context.Background(), "tcp", "example.com:443")
+	elapsed := time.Since(start)
+
+	if err == nil {
+		t.Fatal("DialContext: want an error from an unresponsive proxy, got nil")
+	}
+	if elapsed > 500*time.Millisecond {
+		t.Errorf("DialContext took %v, want it to fail fast around DialTimeout (50ms)", elapsed)
+	}
+}
+
+func TestHTTPProxyDialerDefaultTimeoutIsFifteenSeconds(t *testing.T) {
+	d := &HTTPProxyDialer{}
+	if d.dialTimeout() != 15*time.Second {
+		t.Errorf("dialTimeout() = %v, want 15s", d.dialTimeout())
+	}
+}
+
+// fakeDigestConnectProxy runs a CONNECT proxy that 407s the first attempt
+// on every connection with a Digest challenge, then accepts a second
+// connection bearing a matching Proxy-Authorization: Digest header and
+// tunnels it to upstreamAddr - mirroring a real digest-requiring proxy,
+// which expects the retry on a fresh connection rather than the same one.
+func fakeDigestConnectProxy(t *testing.T, upstreamAddr, username, password string) string {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	t.Cleanup(func() { ln.Close() })
+
+	const nonce = "test-nonce-abc123"
+
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func(conn net.Conn) {
+				defer conn.Close()
+				br := bufio.NewReader(conn)
+				req, err := http.ReadRequest(br)
+				if err != nil || req.Method != http.MethodConnect {
+					return
+				}
+
+				auth := req.Header.Get("Proxy-Authorization")
+				if !strings.HasPrefix(auth, "Digest ") {
+					conn.Write([]byte("HTTP/1.1 407 Proxy Authentication Required\r\n" +
+						`Proxy-Authenticate: Digest realm="proxytest", nonce="` + nonce + `", qop="auth"` + "\r\n\r\n"))
+					return
+				}
+
+				params := parseAuthParams(strings.TrimPrefix(auth, "Digest "))
+				challenge := &digestChallenge{realm: "proxytest", nonce: nonce, qop: "auth"}
+				want := buildDigestResponse(username, password, http.MethodConnect, req.Host, challenge, params["nc"], params["cnonce"])
+				wantParams := parseAuthParams(strings.TrimPrefix(want, "Digest "))
+				if params["response"] != wantParams["response"] {
+					conn.Write([]byte("HTTP/1.1 407 Proxy Authentication Required\r\n" +
+						`Proxy-Authenticate: Digest realm="proxytest", nonce="` + nonce + `", qop="auth"` + "\r\n\r\n"))
+					return
+				}
+
+				upstream, err := net.Dial("tcp", upstreamAddr)
+				if err != nil {
+					conn.Write([]byte("HTTP/1.1 502 Bad Gateway\r\n\r\n"))
+					return
+				}
+				defer upstream.Close()
+
+				conn.Write([]byte("HTTP/1.1 200 Connection Established\r\n\r\n"))
+
+				done := make(chan struct{}, 2)
+				go func() { copyUntilDone(upstream, br, done) }()
+				go func() { copyUntilDone(conn, upstream, done) }()
+				<-done
+			}(conn)
+		}
+	}()
+
+	return ln.Addr().String()
+}
+
+func TestHTTPProxyDialerAnswersDigestChallengeAndTunnels(t *testing.T) {
+	echoLn, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	defer echoLn.Close()
+	go func() {
+		conn, err := echoLn.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		buf := make([]byte, 5)
+		if _, err := readFull(conn, buf); err != nil {
+			return
+		}
+		conn.Write(buf)
+	}()
+
+	proxyAddr := fakeDigestConnectProxy(t, echoLn.Addr().String(), "alice", "correct horse")
+
+	dialer := &HTTPProxyDialer{ProxyAddr: proxyAddr, Username: "alice", Password: "correct horse"}
+	conn, err := dialer.DialContext(context.Background(), "tcp", echoLn.Addr().String())
+	if err != nil {
+		t.Fatalf("DialContext: %v", err)
+	}
+	defer conn.Close()
+
+	if _, err := conn.Write([]byte("hello")); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	buf := make([]byte, 5)
+	if _, err := readFull(conn, buf); err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	if string(buf) != "hello" {
+		t.Errorf("echoed %q, want %q", buf, "hello")
+	}
+}
+
+func TestHTTPProxyDialerDigestWithWrongPasswordFails(t *testing.T) {
+	proxyAddr := fakeDigestConnectProxy(t, "127.0.0.1:1", "alice", "correct horse")
+
+	dialer := &HTTPProxyDialer{ProxyAddr: proxyAddr, Username: "alice", Password: "wrong"}
+	_, err := dialer.DialContext(context.Background(), "tcp", "example.com:443")
+	if err == nil {
+		t.Fatal("DialContext: want an error for a wrong digest password")
+	}
+	if !strings.Contains(err.Error(), "407") {
+		t.Errorf("error = %q, want it to mention the 407 status", err.Error())
+	}
+}
+
+func TestHTTPProxyDialerRejectsNonOKStatus(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	defer ln.Close()
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		conn.Write([]byte("HTTP/1.1 403 Forbidden\r\n\r\n"))
+	}()
+
+	dialer := &HTTPProxyDialer{ProxyAddr: ln.Addr().String()}
+	_, err = dialer.DialContext(context.Background(), "tcp", "example.com:443")
+	if err == nil {
+		t.Fatal("DialContext: want an error for a non-200 CONNECT response")
+	}
+	if !strings.Contains(err.Error(), "403") {
+		t.Errorf("error = %q, want it to mention the 403 status", err.Error())
+	}
+}
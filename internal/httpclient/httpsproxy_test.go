@@ -0,0 +1,165 @@
+package httpclient
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// fakeTLSConnectProxy runs a one-shot TLS-terminating CONNECT proxy:
+// it accepts a TLS connection, reads one CONNECT request, and if
+// wantAuth is non-empty requires a matching Proxy-Authorization header -
+// then answers 200 and tunnels to upstreamAddr by copying bytes both ways.
+func fakeTLSConnectProxy(t *testing.T, cert tls.Certificate, upstreamAddr, wantAuth string) string {
+	t.Helper()
+	ln, err := tls.Listen("tcp", "127.0.0.1:0", &tls.Config{Certificates: []tls.Certificate{cert}})
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	t.Cleanup(func() { ln.Close() })
+
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		br := bufio.NewReader(conn)
+		req, err := http.ReadRequest(br)
+		if err != nil || req.Method != http.MethodConnect {
+			return
+		}
+		if wantAuth != "" && req.Header.Get("Proxy-Authorization") != wantAuth {
+			conn.Write([]byte("HTTP/1.1 407 Proxy Authentication Required\r\n\r\n"))
+			return
+		}
+
+		upstream, err := net.Dial("tcp", upstreamAddr)
+		if err != nil {
+			conn.Write([]byte("HTTP/1.1 502 Bad Gateway\r\n\r\n"))
+			return
+		}
+		defer upstream.Close()
+
+		conn.Write([]byte("HTTP/1.1 200 Connection Established\r\n\r\n"))
+
+		done := make(chan struct{}, 2)
+		go func() { io.Copy(upstream, br); done <- struct{}{} }()
+		go func() { io.Copy(conn, upstream); done <- struct{}{} }()
+		<-done
+	}()
+
+	return ln.Addr().String()
+}
+
+func TestHTTPSProxyDialerTunnelsThroughTLSConnectProxy(t *testing.T) {
+	cert := generateSelfSignedCert(t)
+
+	echoLn, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	defer echoLn.Close()
+	go func() {
+		conn, err := echoLn.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		buf := make([]byte, 5)
+		if _, err := readFull(conn, buf); err != nil {
+			return
+		}
+		conn.Write(buf)
+	}()
+
+	proxyAddr := fakeTLSConnectProxy(t, cert, echoLn.Addr().String(), "")
+
+	dialer := &HTTPSProxyDialer{
+		ProxyAddr: proxyAddr,
+		TLSConfig: &tls.Config{InsecureSkipVerify: true},
+	}
+	conn, err := dialer.DialContext(context.Background(), "tcp", echoLn.Addr().String())
+	if err != nil {
+		t.Fatalf("DialContext: %v", err)
+	}
+	defer conn.Close()
+
+	if _, err := conn.Write([]byte("hello")); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	buf := make([]byte, 5)
+	if _, err := readFull(conn, buf); err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	if string(buf) != "hello" {
+		t.Errorf("echoed %q, want %q", buf, "hello")
+	}
+}
+
+func TestHTTPSProxyDialerSendsProxyAuthorization(t *testing.T) {
+	cert := generateSelfSignedCert(t)
+
+	echoLn, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	defer echoLn.Close()
+	go func() {
+		conn, _ := echoLn.Accept()
+		if conn != nil {
+			conn.Close()
+		}
+	}()
+
+	wantAuth := "Basic " + basicAuthForTest("alice", "hunter2")
+	proxyAddr := fakeTLSConnectProxy(t, cert, echoLn.Addr().String(), wantAuth)
+
+	dialer := &HTTPSProxyDialer{
+		ProxyAddr: proxyAddr,
+		Username:  "alice",
+		Password:  "hunter2",
+		TLSConfig: &tls.Config{InsecureSkipVerify: true},
+	}
+	conn, err := dialer.DialContext(context.Background(), "tcp", echoLn.Addr().String())
+	if err != nil {
+		t.Fatalf("DialContext: %v", err)
+	}
+	conn.Close()
+}
+
+func TestHTTPSProxyDialerRejectsWrongCredentials(t *testing.T) {
+	cert := generateSelfSignedCert(t)
+
+	echoLn, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	defer echoLn.Close()
+
+	proxyAddr := fakeTLSConnectProxy(t, cert, echoLn.Addr().String(), "Basic "+basicAuthForTest("alice", "hunter2"))
+
+	dialer := &HTTPSProxyDialer{
+		ProxyAddr: proxyAddr,
+		TLSConfig: &tls.Config{InsecureSkipVerify: true},
+	}
+	_, err = dialer.DialContext(context.Background(), "tcp", echoLn.Addr().String())
+	if err == nil {
+		t.Fatal("DialContext: want an error for missing credentials, got nil")
+	}
+	if !strings.Contains(err.Error(), "407") {
+		t.Errorf("error = %q, want it to mention the 407 status", err.Error())
+	}
+}
+
+func basicAuthForTest(user, pass string) string {
+	req, _ := http.NewRequest("GET", "http://example.com", nil)
+	req.SetBasicAuth(user, pass)
+	return strings.TrimPrefix(req.Header.Get("Authorization"), "Basic ")
+}
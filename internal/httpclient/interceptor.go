@@ -0,0 +1,22 @@
+package httpclient
+
+import "net/http"
+
+// RequestInterceptor lets callers observe or modify requests and responses
+// around Client.Send, without Client itself knowing about any particular
+// use case (auth signing, logging, header injection, ...).
+type RequestInterceptor interface {
+	// BeforeRequest runs after req is fully built (headers, body, context)
+	// but before it's sent. Implementations may mutate req in place.
+	BeforeRequest(req *http.Request)
+	// AfterResponse runs once the round trip completes successfully, before
+	// the body is read. Implementations may inspect resp but should not
+	// consume its body, since Send still needs to read it.
+	AfterResponse(resp *http.Response)
+}
+
+// AddInterceptor registers interceptor to run on every request sent by c,
+// in registration order.
+func (c *Client) AddInterceptor(interceptor RequestInterceptor) {
+	c.interceptors = append(c.interceptors, interceptor)
+}
@@ -0,0 +1,50 @@
+package httpclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type headerInjectingInterceptor struct {
+	name, value string
+	sawStatus   int
+	afterCalled bool
+}
+
+func (h *headerInjectingInterceptor) BeforeRequest(req *http.Request) {
+	req.Header.Set(h.name, h.value)
+}
+
+func (h *headerInjectingInterceptor) AfterResponse(resp *http.Response) {
+	h.afterCalled = true
+	h.sawStatus = resp.StatusCode
+}
+
+func TestInterceptorInjectsHeaderAndObservesResponse(t *testing.T) {
+	var gotHeader string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotHeader = r.Header.Get("X-Injected")
+		w.WriteHeader(http.StatusTeapot)
+	}))
+	defer srv.Close()
+
+	client := NewClient()
+	interceptor := &headerInjectingInterceptor{name: "X-Injected", value: "hello"}
+	client.AddInterceptor(interceptor)
+
+	if _, err := client.Send(context.Background(), RequestConfig{Method: "GET", URL: srv.URL}); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+
+	if gotHeader != "hello" {
+		t.Errorf("server saw X-Injected = %q, want %q", gotHeader, "hello")
+	}
+	if !interceptor.afterCalled {
+		t.Error("AfterResponse was not called")
+	}
+	if interceptor.sawStatus != http.StatusTeapot {
+		t.Errorf("AfterResponse saw status %d, want %d", interceptor.sawStatus, http.StatusTeapot)
+	}
+}
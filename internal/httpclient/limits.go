@@ -0,0 +1,73 @@
+package httpclient
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// Limits bounds resource usage for a single request so a hostile or
+// misbehaving server can't exhaust memory. The same checks are shared by
+// every transport (the stdlib-backed path in Client.Send today, and
+// CustomH2Transport once its RoundTrip is implemented) via the helpers
+// below, so the two can't silently drift apart.
+type Limits struct {
+	MaxRequestBodyBytes    int64
+	MaxResponseHeaderBytes int64
+	MaxResponseBodyBytes   int64
+}
+
+// DefaultLimits are applied whenever a RequestConfig doesn't specify its own.
+var DefaultLimits = Limits{
+	MaxRequestBodyBytes:    32 << 20,  // 32MB
+	MaxResponseHeaderBytes: 1 << 20,   // 1MB
+	MaxResponseBodyBytes:   100 << 20, // 100MB
+}
+
+// LimitExceededError reports which budget a request tripped.
+type LimitExceededError struct {
+	Reason string
+}
+
+func (e *LimitExceededError) Error() string {
+	return fmt.Sprintf("limit exceeded: %s", e.Reason)
+}
+
+func resolveLimits(l *Limits) Limits {
+	if l == nil {
+		return DefaultLimits
+	}
+	return *l
+}
+
+// checkRequestBodySize aborts early if body exceeds the configured budget,
+// rather than letting it build then fail further down the pipeline.
+func checkRequestBodySize(body string, limits Limits) error {
+	return checkRequestBodyLength(int64(len(body)), limits)
+}
+
+// checkRequestBodyLength is checkRequestBodySize for a body whose size is
+// known without materializing it as a string, e.g. a file on disk.
+func checkRequestBodyLength(n int64, limits Limits) error {
+	if limits.MaxRequestBodyBytes > 0 && n > limits.MaxRequestBodyBytes {
+		return &LimitExceededError{Reason: "request_body"}
+	}
+	return nil
+}
+
+// checkResponseHeaderSize sums the wire-ish size of a response's header
+// names and values and compares it against the budget.
+func checkResponseHeaderSize(header http.Header, limits Limits) error {
+	if limits.MaxResponseHeaderBytes <= 0 {
+		return nil
+	}
+	var size int64
+	for name, values := range header {
+		for _, v := range values {
+			size += int64(len(name)) + int64(len(v)) + 4 // ": " + "\r\n"
+		}
+	}
+	if size > limits.MaxResponseHeaderBytes {
+		return &LimitExceededError{Reason: "response_header"}
+	}
+	return nil
+}
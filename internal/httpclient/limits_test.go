@@ -0,0 +1,98 @@
+package httpclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestSendRequestBodyLimitAbortsEarly(t *testing.T) {
+	client := NewClient()
+	cfg := RequestConfig{
+		Method: "POST",
+		URL:    "http://example.invalid",
+		Body:   strings.Repeat("x", 100),
+		Limits: &Limits{MaxRequestBodyBytes: 10},
+	}
+	_, err := client.Send(context.Background(), cfg)
+	var limitErr *LimitExceededError
+	if err == nil || !asLimitExceeded(err, &limitErr) || limitErr.Reason != "request_body" {
+		t.Fatalf("err = %v, want request_body LimitExceededError", err)
+	}
+}
+
+func TestSendResponseHeaderLimit(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Big", strings.Repeat("y", 1000))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	client := NewClient()
+	cfg := RequestConfig{Method: "GET", URL: srv.URL, Limits: &Limits{MaxResponseHeaderBytes: 100}}
+	_, err := client.Send(context.Background(), cfg)
+	var limitErr *LimitExceededError
+	if err == nil || !asLimitExceeded(err, &limitErr) || limitErr.Reason != "response_header" {
+		t.Fatalf("err = %v, want response_header LimitExceededError", err)
+	}
+}
+
+func TestSendResponseBodyLimit(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(strings.Repeat("z", 1000)))
+	}))
+	defer srv.Close()
+
+	client := NewClient()
+	cfg := RequestConfig{Method: "GET", URL: srv.URL, Limits: &Limits{MaxResponseBodyBytes: 100}}
+	_, err := client.Send(context.Background(), cfg)
+	var limitErr *LimitExceededError
+	if err == nil || !asLimitExceeded(err, &limitErr) || limitErr.Reason != "response_body" {
+		t.Fatalf("err = %v, want response_body LimitExceededError", err)
+	}
+}
+
+func TestSendMaxResponseBytesOverridesDefaultLimit(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(strings.Repeat("z", 1000)))
+	}))
+	defer srv.Close()
+
+	client := NewClient()
+	cfg := RequestConfig{Method: "GET", URL: srv.URL, MaxResponseBytes: 100}
+	_, err := client.Send(context.Background(), cfg)
+	var limitErr *LimitExceededError
+	if err == nil || !asLimitExceeded(err, &limitErr) || limitErr.Reason != "response_body" {
+		t.Fatalf("err = %v, want response_body LimitExceededError", err)
+	}
+}
+
+func TestSendMaxResponseBytesTakesPrecedenceOverLimits(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(strings.Repeat("z", 1000)))
+	}))
+	defer srv.Close()
+
+	client := NewClient()
+	cfg := RequestConfig{
+		Method:           "GET",
+		URL:              srv.URL,
+		Limits:           &Limits{MaxResponseBodyBytes: 10000},
+		MaxResponseBytes: 100,
+	}
+	_, err := client.Send(context.Background(), cfg)
+	var limitErr *LimitExceededError
+	if err == nil || !asLimitExceeded(err, &limitErr) || limitErr.Reason != "response_body" {
+		t.Fatalf("err = %v, want response_body LimitExceededError", err)
+	}
+}
+
+func asLimitExceeded(err error, target **LimitExceededError) bool {
+	le, ok := err.(*LimitExceededError)
+	if ok {
+		*target = le
+	}
+	return ok
+}
@@ -0,0 +1,45 @@
+package httpclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestMaxConnsPerHostBoundsConcurrentConnections(t *testing.T) {
+	var current, peak int64
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt64(&current, 1)
+		for {
+			p := atomic.LoadInt64(&peak)
+			if n <= p || atomic.CompareAndSwapInt64(&peak, p, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		atomic.AddInt64(&current, -1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	client := NewClient()
+	client.SetMaxConnsPerHost(2)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			client.Send(context.Background(), RequestConfig{Method: "GET", URL: srv.URL})
+		}()
+	}
+	wg.Wait()
+
+	if peak > 2 {
+		t.Errorf("peak concurrent connections = %d, want <= 2", peak)
+	}
+}
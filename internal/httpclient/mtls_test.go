@@ -0,0 +1,118 @@
+package httpclient
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// generateSelfSignedClientCert is generateSelfSignedCert's counterpart for
+// the client side of a handshake: ExtKeyUsageClientAuth instead of
+// ExtKeyUsageServerAuth, since a server verifying a client cert checks for it.
+func generateSelfSignedClientCert(t *testing.T) tls.Certificate {
+	t.Helper()
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "test-client"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, &template, &template, &priv.PublicKey, priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: priv}
+}
+
+// certToPEM encodes a tls.Certificate's leaf and private key as PEM, the
+// form TlsConfig.ClientCertPEM/ClientKeyPEM expect.
+func certToPEM(t *testing.T, cert tls.Certificate) (certPEM, keyPEM string) {
+	t.Helper()
+	certBlock := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Certificate[0]})
+	keyBytes, err := x509.MarshalECPrivateKey(cert.PrivateKey.(*ecdsa.PrivateKey))
+	if err != nil {
+		t.Fatalf("MarshalECPrivateKey: %v", err)
+	}
+	keyBlock := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyBytes})
+	return string(certBlock), string(keyBlock)
+}
+
+func TestDialTLSPresentsClientCertForMutualTLS(t *testing.T) {
+	serverCert := generateSelfSignedCert(t)
+	clientCert := generateSelfSignedClientCert(t)
+
+	clientCertPool := x509.NewCertPool()
+	leaf, err := x509.ParseCertificate(clientCert.Certificate[0])
+	if err != nil {
+		t.Fatalf("ParseCertificate: %v", err)
+	}
+	clientCertPool.AddCert(leaf)
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+
+	tlsLn := tls.NewListener(ln, &tls.Config{
+		Certificates: []tls.Certificate{serverCert},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    clientCertPool,
+	})
+
+	srv := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})}
+	go srv.Serve(tlsLn)
+	defer srv.Close()
+
+	certPEM, keyPEM := certToPEM(t, clientCert)
+
+	client := NewClient()
+	resp, err := client.Send(context.Background(), RequestConfig{
+		Method: "GET",
+		URL:    "https://" + ln.Addr().String(),
+		Tls:    &TlsConfig{ClientCertPEM: certPEM, ClientKeyPEM: keyPEM},
+	})
+	if err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("StatusCode = %d, want 200", resp.StatusCode)
+	}
+}
+
+func TestDialTLSMalformedClientCertReturnsClearError(t *testing.T) {
+	client := NewClient()
+	ctx := withTlsConfig(context.Background(), &TlsConfig{ClientCertPEM: "not a cert", ClientKeyPEM: "not a key"})
+
+	_, err := client.dialTLS(ctx, "tcp", "127.0.0.1:1")
+	if err == nil {
+		t.Fatal("expected an error for a malformed client certificate")
+	}
+
+	var tlsErr *TlsHandshakeError
+	if !errors.As(err, &tlsErr) {
+		t.Fatalf("error = %v (%T), want *TlsHandshakeError", err, err)
+	}
+	if tlsErr.Category != TlsErrorClientCertInvalid {
+		t.Errorf("Category = %q, want %q", tlsErr.Category, TlsErrorClientCertInvalid)
+	}
+}
@@ -0,0 +1,94 @@
+package httpclient
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/textproto"
+	"os"
+	"strings"
+)
+
+// escapeQuotedString escapes "\" and "\"" the way mime/multipart's own
+// CreateFormField/CreateFormFile do internally (its escapeQuotes isn't
+// exported), so a Name or Filename containing a quote can't break out of
+// the quoted Content-Disposition attribute it's embedded in.
+var escapeQuotedString = strings.NewReplacer("\\", "\\\\", `"`, "\\\"").Replace
+
+// FormDataField is one part of a multipart/form-data request body. This is
+// a slice, not a map, so callers can control part order exactly - some
+// servers are order-sensitive, and a captured browser request needs to be
+// reproduced byte-for-byte - and so duplicate Names are fully supported
+// (e.g. several parts all named "files[]").
+type FormDataField struct {
+	Name        string
+	Value       string
+	Filename    string
+	ContentType string
+
+	// FilePath, when set, streams that file's contents from disk as the
+	// part's body instead of using Value - for uploads too large to want
+	// buffered as a string first. Value is ignored when FilePath is set.
+	FilePath string
+}
+
+// encodeMultipartFormData writes fields into a new multipart body in order,
+// returning the encoded body and the Content-Type header value (including
+// the boundary) to send with it. multipart.NewWriter randomizes the
+// boundary per call, so it's never reused across requests.
+func encodeMultipartFormData(fields []FormDataField) ([]byte, string, error) {
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+
+	for _, f := range fields {
+		part, err := createFormPart(w, f)
+		if err != nil {
+			return nil, "", err
+		}
+		if err := writeFormPartContent(part, f); err != nil {
+			return nil, "", err
+		}
+	}
+	if err := w.Close(); err != nil {
+		return nil, "", err
+	}
+	return buf.Bytes(), w.FormDataContentType(), nil
+}
+
+// writeFormPartContent writes f's content into part: the file at FilePath
+// when set, otherwise Value.
+func writeFormPartContent(part io.Writer, f FormDataField) error {
+	if f.FilePath == "" {
+		_, err := part.Write([]byte(f.Value))
+		return err
+	}
+
+	file, err := os.Open(f.FilePath)
+	if err != nil {
+		return fmt.Errorf("httpclient: form field %q: opening %q: %w", f.Name, f.FilePath, err)
+	}
+	defer file.Close()
+
+	if _, err := io.Copy(part, file); err != nil {
+		return fmt.Errorf("httpclient: form field %q: reading %q: %w", f.Name, f.FilePath, err)
+	}
+	return nil
+}
+
+// createFormPart creates f's part, choosing a plain form field, a
+// CreateFormFile-style file part, or (when a custom ContentType is given) a
+// part with an explicit header so the sent Content-Type matches exactly.
+func createFormPart(w *multipart.Writer, f FormDataField) (io.Writer, error) {
+	if f.Filename == "" {
+		return w.CreateFormField(f.Name)
+	}
+	if f.ContentType == "" {
+		return w.CreateFormFile(f.Name, f.Filename)
+	}
+	header := make(textproto.MIMEHeader)
+	header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="%s"; filename="%s"`,
+		escapeQuotedString(f.Name), escapeQuotedString(f.Filename)))
+	header.Set("Content-Type", f.ContentType)
+	return w.CreatePart(header)
+}
@@ -0,0 +1,136 @@
+package httpclient
+
+import (
+	"io"
+	"mime"
+	"mime/multipart"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestEncodeMultipartFormDataPreservesOrderAndDuplicates(t *testing.T) {
+	fields := []FormDataField{
+		{Name: "field1", Value: "a"},
+		{Name: "files[]", Value: "one", Filename: "a.txt"},
+		{Name: "files[]", Value: "two", Filename: "b.txt"},
+	}
+
+	body, contentType, err := encodeMultipartFormData(fields)
+	if err != nil {
+		t.Fatalf("encodeMultipartFormData: %v", err)
+	}
+
+	_, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		t.Fatalf("ParseMediaType: %v", err)
+	}
+	reader := multipart.NewReader(strings.NewReader(string(body)), params["boundary"])
+
+	var names, filenames []string
+	for {
+		part, err := reader.NextPart()
+		if err != nil {
+			break
+		}
+		names = append(names, part.FormName())
+		filenames = append(filenames, part.FileName())
+	}
+
+	wantNames := []string{"field1", "files[]", "files[]"}
+	wantFiles := []string{"", "a.txt", "b.txt"}
+	if len(names) != len(wantNames) {
+		t.Fatalf("got %d parts, want %d", len(names), len(wantNames))
+	}
+	for i := range wantNames {
+		if names[i] != wantNames[i] || filenames[i] != wantFiles[i] {
+			t.Errorf("part %d = (%q, %q), want (%q, %q)", i, names[i], filenames[i], wantNames[i], wantFiles[i])
+		}
+	}
+}
+
+func TestEncodeMultipartFormDataStreamsFileFromDisk(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "upload.txt")
+	if err := os.WriteFile(path, []byte("file contents"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	fields := []FormDataField{{Name: "upload", Filename: "upload.txt", FilePath: path}}
+	body, contentType, err := encodeMultipartFormData(fields)
+	if err != nil {
+		t.Fatalf("encodeMultipartFormData: %v", err)
+	}
+
+	_, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		t.Fatalf("ParseMediaType: %v", err)
+	}
+	reader := multipart.NewReader(strings.NewReader(string(body)), params["boundary"])
+	part, err := reader.NextPart()
+	if err != nil {
+		t.Fatalf("NextPart: %v", err)
+	}
+	got, err := io.ReadAll(part)
+	if err != nil {
+		t.Fatalf("reading part: %v", err)
+	}
+	if string(got) != "file contents" {
+		t.Errorf("part content = %q, want %q", got, "file contents")
+	}
+}
+
+func TestEncodeMultipartFormDataMissingFileReturnsClearError(t *testing.T) {
+	fields := []FormDataField{{Name: "upload", Filename: "missing.txt", FilePath: "/nonexistent/missing.txt"}}
+	_, _, err := encodeMultipartFormData(fields)
+	if err == nil {
+		t.Fatal("expected an error for a missing file")
+	}
+	if !strings.Contains(err.Error(), "/nonexistent/missing.txt") {
+		t.Errorf("error = %v, want it to mention the missing path", err)
+	}
+}
+
+func TestEncodeMultipartFormDataEscapesQuotesInCustomContentTypePart(t *testing.T) {
+	fields := []FormDataField{{
+		Name:        `field"1`,
+		Value:       "payload",
+		Filename:    `evil".txt`,
+		ContentType: "text/plain",
+	}}
+
+	body, contentType, err := encodeMultipartFormData(fields)
+	if err != nil {
+		t.Fatalf("encodeMultipartFormData: %v", err)
+	}
+
+	_, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		t.Fatalf("ParseMediaType: %v", err)
+	}
+	reader := multipart.NewReader(strings.NewReader(string(body)), params["boundary"])
+	part, err := reader.NextPart()
+	if err != nil {
+		t.Fatalf("NextPart: %v", err)
+	}
+	if got, want := part.FormName(), `field"1`; got != want {
+		t.Errorf("FormName() = %q, want %q", got, want)
+	}
+	if got, want := part.FileName(), `evil".txt`; got != want {
+		t.Errorf("FileName() = %q, want %q", got, want)
+	}
+}
+
+func TestSendWithFormDataSetsMultipartContentType(t *testing.T) {
+	fields := []FormDataField{{Name: "a", Value: "1"}}
+	body, contentType, err := encodeMultipartFormData(fields)
+	if err != nil {
+		t.Fatalf("encodeMultipartFormData: %v", err)
+	}
+	if !strings.HasPrefix(contentType, "multipart/form-data; boundary=") {
+		t.Errorf("contentType = %q, want a multipart/form-data boundary header", contentType)
+	}
+	if len(body) == 0 {
+		t.Error("expected non-empty encoded body")
+	}
+}
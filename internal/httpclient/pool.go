@@ -0,0 +1,132 @@
+package httpclient
+
+import (
+	"net"
+	"sync"
+	"time"
+)
+
+// defaultIdleConnTimeout is used when connPool isn't given an explicit idle
+// timeout - long enough to survive the gap between requests in a typical
+// SendRequestRepeat burst, short enough not to pile up dead connections a
+// server has since dropped.
+const defaultIdleConnTimeout = 90 * time.Second
+
+// pooledConn is an idle connection plus when it was returned to the pool, so
+// get can evict entries that have sat idle past the pool's timeout instead
+// of handing back a connection the peer (or an intervening proxy) has
+// likely already closed.
+type pooledConn struct {
+	conn      net.Conn
+	idleSince time.Time
+}
+
+// connPool keeps idle connections keyed by authority (host:port) so a
+// future CustomH2Transport.RoundTrip could reuse a connection across
+// requests instead of paying a fresh TLS handshake (and losing
+// fingerprint-affecting session state) every time. RoundTrip doesn't exist
+// yet (see CustomH2Transport's doc comment), so nothing currently calls
+// connPool.get/put outside its own tests.
+type connPool struct {
+	mu          sync.Mutex
+	idle        map[string][]pooledConn
+	idleTimeout time.Duration
+}
+
+func newConnPool() *connPool {
+	return newConnPoolWithTimeout(defaultIdleConnTimeout)
+}
+
+// newConnPoolWithTimeout builds a connPool that evicts (and closes)
+// connections idle longer than idleTimeout. idleTimeout <= 0 means
+// connections never expire on their own.
+func newConnPoolWithTimeout(idleTimeout time.Duration) *connPool {
+	return &connPool{idle: make(map[string][]pooledConn), idleTimeout: idleTimeout}
+}
+
+// get returns a pooled idle connection for key, if one exists and hasn't
+// exceeded the pool's idle timeout. Expired connections are closed and
+// discarded rather than returned.
+func (p *connPool) get(key string) (net.Conn, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	conns := p.idle[key]
+	for len(conns) > 0 {
+		last := conns[len(conns)-1]
+		conns = conns[:len(conns)-1]
+		if p.idleTimeout > 0 && timeNow().Sub(last.idleSince) > p.idleTimeout {
+			last.conn.Close()
+			continue
+		}
+		p.idle[key] = conns
+		return last.conn, true
+	}
+	p.idle[key] = conns
+	return nil, false
+}
+
+// put returns conn to the pool for reuse under key.
+func (p *connPool) put(key string, conn net.Conn) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.idle[key] = append(p.idle[key], pooledConn{conn: conn, idleSince: timeNow()})
+}
+
+// closeAll closes every idle connection the pool is holding.
+func (p *connPool) closeAll() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for key, conns := range p.idle {
+		for _, c := range conns {
+			c.conn.Close()
+		}
+		delete(p.idle, key)
+	}
+}
+
+// connClosingBody wraps a response body so closing it either returns the
+// underlying connection to pool for reuse (the common case) or hard-closes
+// it, when the server or caller indicated the connection can't be reused -
+// e.g. a "Connection: close" response header, or an HTTP/2 GOAWAY. Like the
+// rest of connPool, this is plumbing for a future CustomH2Transport.RoundTrip
+// and isn't exercised by any real request today.
+type connClosingBody struct {
+	body       net.Conn // the raw connection, so Close can decide its fate
+	readCloser readCloser
+	pool       *connPool
+	key        string
+	forceClose bool
+}
+
+// readCloser is the minimal surface connClosingBody needs from the
+// underlying response body reader.
+type readCloser interface {
+	Read(p []byte) (int, error)
+	Close() error
+}
+
+func newConnClosingBody(rc readCloser, conn net.Conn, pool *connPool, key string, forceClose bool) *connClosingBody {
+	return &connClosingBody{readCloser: rc, body: conn, pool: pool, key: key, forceClose: forceClose}
+}
+
+func (b *connClosingBody) Read(p []byte) (int, error) {
+	return b.readCloser.Read(p)
+}
+
+// Close returns the connection to the pool for reuse unless forceClose was
+// set (Connection: close / GOAWAY was observed), in which case it closes
+// the connection outright.
+func (b *connClosingBody) Close() error {
+	if err := b.readCloser.Close(); err != nil {
+		return err
+	}
+	if b.forceClose || b.pool == nil || b.body == nil {
+		if b.body != nil {
+			return b.body.Close()
+		}
+		return nil
+	}
+	b.pool.put(b.key, b.body)
+	return nil
+}
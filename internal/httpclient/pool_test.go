@@ -0,0 +1,108 @@
+package httpclient
+
+import (
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+type fakeReadCloser struct {
+	io.Reader
+	closed bool
+}
+
+func (f *fakeReadCloser) Close() error {
+	f.closed = true
+	return nil
+}
+
+func TestConnClosingBodyReturnsConnToPoolByDefault(t *testing.T) {
+	pool := newConnPool()
+	client, server := net.Pipe()
+	defer server.Close()
+
+	rc := &fakeReadCloser{Reader: strings.NewReader("body")}
+	body := newConnClosingBody(rc, client, pool, "example.com:443", false)
+
+	if err := body.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if !rc.closed {
+		t.Error("underlying reader was not closed")
+	}
+	if _, ok := pool.get("example.com:443"); !ok {
+		t.Error("connection was not returned to the pool")
+	}
+}
+
+func TestConnClosingBodyForceCloseSkipsPool(t *testing.T) {
+	pool := newConnPool()
+	client, server := net.Pipe()
+	defer server.Close()
+
+	rc := &fakeReadCloser{Reader: strings.NewReader("body")}
+	body := newConnClosingBody(rc, client, pool, "example.com:443", true)
+
+	if err := body.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if _, ok := pool.get("example.com:443"); ok {
+		t.Error("forceClose connection should not have been pooled")
+	}
+}
+
+func TestConnPoolEvictsConnectionsPastIdleTimeout(t *testing.T) {
+	defer func(orig func() time.Time) { timeNow = orig }(timeNow)
+	now := time.Now()
+	timeNow = func() time.Time { return now }
+
+	pool := newConnPoolWithTimeout(time.Minute)
+	client, server := net.Pipe()
+	defer server.Close()
+	defer client.Close()
+
+	pool.put("example.com:443", client)
+	now = now.Add(2 * time.Minute)
+
+	if _, ok := pool.get("example.com:443"); ok {
+		t.Error("get returned a connection past the idle timeout, want eviction")
+	}
+}
+
+func TestConnPoolServesConnectionsWithinIdleTimeout(t *testing.T) {
+	defer func(orig func() time.Time) { timeNow = orig }(timeNow)
+	now := time.Now()
+	timeNow = func() time.Time { return now }
+
+	pool := newConnPoolWithTimeout(time.Minute)
+	client, server := net.Pipe()
+	defer server.Close()
+	defer client.Close()
+
+	pool.put("example.com:443", client)
+	now = now.Add(30 * time.Second)
+
+	if _, ok := pool.get("example.com:443"); !ok {
+		t.Error("get evicted a connection within the idle timeout")
+	}
+}
+
+func TestConnPoolZeroTimeoutNeverExpires(t *testing.T) {
+	defer func(orig func() time.Time) { timeNow = orig }(timeNow)
+	now := time.Now()
+	timeNow = func() time.Time { return now }
+
+	pool := newConnPoolWithTimeout(0)
+	client, server := net.Pipe()
+	defer server.Close()
+	defer client.Close()
+
+	pool.put("example.com:443", client)
+	now = now.Add(24 * time.Hour)
+
+	if _, ok := pool.get("example.com:443"); !ok {
+		t.Error("get evicted a connection despite a zero (never-expire) timeout")
+	}
+}
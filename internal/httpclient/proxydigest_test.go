@@ -0,0 +1,46 @@
+package httpclient
+
+import "testing"
+
+func TestParseDigestChallengeExtractsFields(t *testing.T) {
+	header := `Digest realm="proxytest", nonce="abc123", qop="auth,auth-int", opaque="xyz"`
+	c, err := parseDigestChallenge(header)
+	if err != nil {
+		t.Fatalf("parseDigestChallenge: %v", err)
+	}
+	if c.realm != "proxytest" || c.nonce != "abc123" || c.opaque != "xyz" {
+		t.Errorf("got %+v, want realm=proxytest nonce=abc123 opaque=xyz", c)
+	}
+	if c.qop != "auth" {
+		t.Errorf("qop = %q, want auth preferred over auth-int", c.qop)
+	}
+}
+
+func TestParseDigestChallengeRejectsNonDigestScheme(t *testing.T) {
+	if _, err := parseDigestChallenge(`Basic realm="proxytest"`); err == nil {
+		t.Fatal("parseDigestChallenge: want an error for a non-Digest challenge")
+	}
+}
+
+func TestBuildDigestResponseIsDeterministicForSameInputs(t *testing.T) {
+	c := &digestChallenge{realm: "proxytest", nonce: "abc123", qop: "auth"}
+	a := buildDigestResponse("alice", "secret", "CONNECT", "example.com:443", c, "00000001", "cnonce1")
+	b := buildDigestResponse("alice", "secret", "CONNECT", "example.com:443", c, "00000001", "cnonce1")
+	if a != b {
+		t.Errorf("buildDigestResponse not deterministic: %q != %q", a, b)
+	}
+
+	wrong := buildDigestResponse("alice", "wrong", "CONNECT", "example.com:443", c, "00000001", "cnonce1")
+	if a == wrong {
+		t.Error("buildDigestResponse: different passwords produced the same response")
+	}
+}
+
+func TestBuildDigestResponseHandlesAuthInt(t *testing.T) {
+	c := &digestChallenge{realm: "proxytest", nonce: "abc123", qop: "auth-int"}
+	got := buildDigestResponse("alice", "secret", "CONNECT", "example.com:443", c, "00000001", "cnonce1")
+	params := parseAuthParams(got[len("Digest "):])
+	if params["qop"] != "auth-int" {
+		t.Errorf("qop = %q, want auth-int", params["qop"])
+	}
+}
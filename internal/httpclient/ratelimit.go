@@ -0,0 +1,72 @@
+package httpclient
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// RateLimiter is a token-bucket limiter. A single instance shared across
+// every request a Client sends gives the whole process one QPS cap -
+// manual sends, SendRepeat iterations, and anything else using the same
+// Client - instead of each caller needing its own throttling.
+type RateLimiter struct {
+	mu         sync.Mutex
+	tokens     float64
+	maxTokens  float64
+	refillRate float64 // tokens per second
+	last       time.Time
+}
+
+// NewRateLimiter returns a limiter allowing qps requests per second on
+// average, with bursts up to burst requests before it starts blocking.
+func NewRateLimiter(qps float64, burst int) *RateLimiter {
+	return &RateLimiter{
+		tokens:     float64(burst),
+		maxTokens:  float64(burst),
+		refillRate: qps,
+	}
+}
+
+// Wait blocks until a token is available or ctx is done, whichever comes
+// first.
+func (r *RateLimiter) Wait(ctx context.Context) error {
+	for {
+		wait := r.reserveOrWait()
+		if wait <= 0 {
+			return nil
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
+	}
+}
+
+// reserveOrWait takes a token if one is available, returning 0. Otherwise
+// it returns how long the caller should wait before trying again.
+func (r *RateLimiter) reserveOrWait() time.Duration {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.refillLocked(timeNow())
+	if r.tokens >= 1 {
+		r.tokens--
+		return 0
+	}
+	return time.Duration((1 - r.tokens) / r.refillRate * float64(time.Second))
+}
+
+func (r *RateLimiter) refillLocked(now time.Time) {
+	if r.last.IsZero() {
+		r.last = now
+		return
+	}
+	elapsed := now.Sub(r.last).Seconds()
+	r.tokens = min(r.maxTokens, r.tokens+elapsed*r.refillRate)
+	r.last = now
+}
@@ -0,0 +1,61 @@
+package httpclient
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestRateLimiterAllowsBurstThenGatesToRefillRate(t *testing.T) {
+	origNow := timeNow
+	defer func() { timeNow = origNow }()
+
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	timeNow = func() time.Time { return base }
+
+	limiter := NewRateLimiter(10, 2) // 10 qps, burst of 2
+
+	// Burst: two tokens available immediately, no waiting required.
+	if wait := limiter.reserveOrWait(); wait != 0 {
+		t.Fatalf("first reserve: wait = %v, want 0", wait)
+	}
+	if wait := limiter.reserveOrWait(); wait != 0 {
+		t.Fatalf("second reserve: wait = %v, want 0", wait)
+	}
+
+	// Bucket is empty now; at 10 qps the next token needs 100ms.
+	wait := limiter.reserveOrWait()
+	if wait != 100*time.Millisecond {
+		t.Fatalf("third reserve: wait = %v, want 100ms", wait)
+	}
+
+	// Advancing the clock by the reported wait should make a token available.
+	timeNow = func() time.Time { return base.Add(100 * time.Millisecond) }
+	if wait := limiter.reserveOrWait(); wait != 0 {
+		t.Fatalf("reserve after refill: wait = %v, want 0", wait)
+	}
+}
+
+func TestRateLimiterWaitReturnsContextErrorOnCancel(t *testing.T) {
+	limiter := NewRateLimiter(1, 0) // no burst, always has to wait
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := limiter.Wait(ctx); err != context.Canceled {
+		t.Fatalf("Wait = %v, want context.Canceled", err)
+	}
+}
+
+func TestRateLimiterWaitUnblocksOnceTokenAvailable(t *testing.T) {
+	limiter := NewRateLimiter(1000, 1) // fast refill so the test stays quick
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	for i := 0; i < 3; i++ {
+		if err := limiter.Wait(ctx); err != nil {
+			t.Fatalf("Wait #%d: %v", i, err)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package httpclient
+
+import (
+	"context"
+	"net"
+	"sync"
+)
+
+// loggedConn wraps a net.Conn, capturing every byte read or written for
+// later inspection via Bytes, and suppressing the real Close so the socket
+// stays open for RequestConfig.RetainRawConn's manual-inspection use case -
+// the caller must call release (via Client.ReleaseRawConn) to actually free
+// it, rather than it closing (and the log becoming unreachable) the moment
+// the transport is done with the response.
+type loggedConn struct {
+	net.Conn
+	mu     sync.Mutex
+	log    []byte
+	closed bool
+}
+
+func newLoggedConn(conn net.Conn) *loggedConn {
+	return &loggedConn{Conn: conn}
+}
+
+func (c *loggedConn) Read(p []byte) (int, error) {
+	n, err := c.Conn.Read(p)
+	if n > 0 {
+		c.mu.Lock()
+		c.log = append(c.log, p[:n]...)
+		c.mu.Unlock()
+	}
+	return n, err
+}
+
+func (c *loggedConn) Write(p []byte) (int, error) {
+	n, err := c.Conn.Write(p)
+	if n > 0 {
+		c.mu.Lock()
+		c.log = append(c.log, p[:n]...)
+		c.mu.Unlock()
+	}
+	return n, err
+}
+
+// Bytes returns a copy of everything logged so far.
+func (c *loggedConn) Bytes() []byte {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]byte(nil), c.log...)
+}
+
+// Close is a deliberate no-op: see the type doc comment. The transport
+// calls this believing it's releasing the connection; release is what
+// actually closes it.
+func (c *loggedConn) Close() error {
+	return nil
+}
+
+// release actually closes the underlying connection, regardless of how
+// many times Close was called on it.
+func (c *loggedConn) release() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return nil
+	}
+	c.closed = true
+	return c.Conn.Close()
+}
+
+// rawConnHolder is how dialTLS hands the loggedConn it created back to
+// Send, the same context-value pattern withTlsInfoHolder uses - a context
+// value is the only way to get data out of dialTLS, since http.Transport's
+// DialTLSContext signature only returns a net.Conn.
+type rawConnHolder struct {
+	conn *loggedConn
+}
+
+func withRawConnHolder(ctx context.Context, holder *rawConnHolder) context.Context {
+	return context.WithValue(ctx, rawConnHolderCtxKey, holder)
+}
+
+func rawConnHolderFromContext(ctx context.Context) *rawConnHolder {
+	holder, _ := ctx.Value(rawConnHolderCtxKey).(*rawConnHolder)
+	return holder
+}
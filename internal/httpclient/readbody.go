@@ -0,0 +1,21 @@
+package httpclient
+
+import "io"
+
+// readResponseBody reads r (typically resp.Body, possibly wrapped in an
+// io.LimitReader for MaxResponseBodyBytes) to completion, tolerating a read
+// error instead of discarding whatever was read so far. A read error here
+// usually means the connection was cut mid-body - a read error, an HTTP/2
+// GOAWAY or RST_STREAM, or a deadline firing - and net/http surfaces all of
+// those the same way: io.ReadAll returning a non-nil error alongside
+// however many bytes it managed to read. Presenting that partial body as if
+// it were complete would be worse than admitting it's truncated, so the
+// caller gets both back and records it on ResponseData via
+// Incomplete/TruncationReason instead of failing the whole request.
+func readResponseBody(r io.Reader) (body []byte, incomplete bool, truncationReason string) {
+	body, err := io.ReadAll(r)
+	if err != nil {
+		return body, true, err.Error()
+	}
+	return body, false, ""
+}
@@ -0,0 +1,95 @@
+package httpclient
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// maxRedirects mirrors net/http's own default redirect cap; checkRedirect
+// enforces it itself since setting Client.CheckRedirect at all overrides
+// that default behavior.
+const maxRedirects = 10
+
+// RedirectHop records one hop of a followed redirect chain, for debugging
+// why a cookie did or didn't make it across - e.g. confirming a jar-scoped
+// cookie was correctly dropped on a cross-domain hop.
+type RedirectHop struct {
+	URL string `json:"url"`
+	// CookiesSent names the cookies the jar will attach to the request for
+	// this hop, empty if none apply. http.Client only actually calls
+	// Jar.Cookies and adds them to the request after CheckRedirect returns,
+	// so checkRedirect queries the jar itself to report this.
+	CookiesSent []string `json:"cookiesSent,omitempty"`
+}
+
+// withRedirectHops attaches a *[]RedirectHop to ctx for checkRedirect to
+// append to as Client.Do follows each hop.
+func withRedirectHops(ctx context.Context, hops *[]RedirectHop) context.Context {
+	return context.WithValue(ctx, redirectHopsCtxKey, hops)
+}
+
+func redirectHopsFromContext(ctx context.Context) *[]RedirectHop {
+	hops, _ := ctx.Value(redirectHopsCtxKey).(*[]RedirectHop)
+	return hops
+}
+
+// checkRedirect is installed as http.Client.CheckRedirect. net/http only
+// attaches Client.Jar's cookies to req once this returns and it actually
+// sends the request, so to report what the jar will send on this hop,
+// checkRedirect asks the jar directly rather than reading req.Header.
+func (c *Client) checkRedirect(req *http.Request, via []*http.Request) error {
+	if len(via) >= maxRedirects {
+		return fmt.Errorf("httpclient: stopped after %d redirects", maxRedirects)
+	}
+	if hops := redirectHopsFromContext(req.Context()); hops != nil {
+		var cookiesSent []string
+		if jar := c.httpClient.Jar; jar != nil {
+			for _, cookie := range jar.Cookies(req.URL) {
+				cookiesSent = append(cookiesSent, cookie.Name+"="+cookie.Value)
+			}
+		}
+		*hops = append(*hops, RedirectHop{URL: req.URL.String(), CookiesSent: cookiesSent})
+	}
+	return nil
+}
+
+// redirectBodyDrainCap bounds how much of an intermediate redirect hop's
+// response body drainCappingTransport will read on Close, so a large body
+// on a hop nobody reads (the caller only ever sees the final response)
+// can't balloon memory or block for long. net/http itself drains a small,
+// undocumented amount of an unread body to enable connection reuse; this
+// just makes that bound explicit and a bit larger.
+const redirectBodyDrainCap = 64 * 1024
+
+// drainCappingTransport wraps every response body in cappedDrainBody so
+// that closing it without reading it to EOF - exactly what happens to
+// each intermediate hop's response during automatic redirect-following -
+// only ever buffers up to redirectBodyDrainCap bytes. The final response of
+// a chain is usually read to EOF before it is closed, leaving nothing to
+// drain; when reading stops early (a body size limit trips, or a SendStream
+// callback aborts), Close drains at most redirectBodyDrainCap bytes of it.
+type drainCappingTransport struct {
+	http.RoundTripper
+}
+
+func (t drainCappingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.RoundTripper.RoundTrip(req)
+	if err != nil || resp == nil {
+		return resp, err
+	}
+	resp.Body = &cappedDrainBody{ReadCloser: resp.Body}
+	return resp, nil
+}
+
+// cappedDrainBody passes Read straight through but bounds the drain that
+// Close performs on whatever the caller left unread.
+type cappedDrainBody struct {
+	io.ReadCloser
+}
+
+func (b *cappedDrainBody) Close() error {
+	io.CopyN(io.Discard, b.ReadCloser, redirectBodyDrainCap)
+	return b.ReadCloser.Close()
+}
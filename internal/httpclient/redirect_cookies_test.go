@@ -0,0 +1,69 @@
+package httpclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestSendCarriesCookieAcrossSameHostRedirect(t *testing.T) {
+	var gotCookie string
+	var mux http.ServeMux
+	srv := httptest.NewServer(&mux)
+	defer srv.Close()
+
+	mux.HandleFunc("/start", func(w http.ResponseWriter, r *http.Request) {
+		http.SetCookie(w, &http.Cookie{Name: "session", Value: "abc123"})
+		http.Redirect(w, r, srv.URL+"/next", http.StatusFound)
+	})
+	mux.HandleFunc("/next", func(w http.ResponseWriter, r *http.Request) {
+		if c, err := r.Cookie("session"); err == nil {
+			gotCookie = c.Value
+		}
+	})
+
+	client := NewClient()
+	resp, err := client.Send(context.Background(), RequestConfig{Method: "GET", URL: srv.URL + "/start"})
+	if err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if gotCookie != "abc123" {
+		t.Errorf("cookie on second hop = %q, want %q", gotCookie, "abc123")
+	}
+	if len(resp.RedirectHops) != 1 {
+		t.Fatalf("RedirectHops = %+v, want 1 entry", resp.RedirectHops)
+	}
+	if len(resp.RedirectHops[0].CookiesSent) == 0 {
+		t.Errorf("RedirectHops[0].CookiesSent is empty, want the session cookie recorded")
+	}
+}
+
+func TestSendDoesNotLeakCookieAcrossDomains(t *testing.T) {
+	var gotCookieHeader string
+	other := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotCookieHeader = r.Header.Get("Cookie")
+	}))
+	defer other.Close()
+
+	// Redirect to "localhost" rather than other's own "127.0.0.1" URL so this
+	// genuinely crosses hosts in the jar's eyes, instead of merely crossing
+	// ports on the same host (which cookies, unlike TCP connections, don't
+	// scope by).
+	otherHost := strings.Replace(other.URL, "127.0.0.1", "localhost", 1)
+	start := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.SetCookie(w, &http.Cookie{Name: "session", Value: "secret"})
+		http.Redirect(w, r, otherHost, http.StatusFound)
+	}))
+	defer start.Close()
+
+	client := NewClient()
+	_, err := client.Send(context.Background(), RequestConfig{Method: "GET", URL: start.URL})
+	if err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if gotCookieHeader != "" {
+		t.Errorf("Cookie header leaked to a different host: %q", gotCookieHeader)
+	}
+}
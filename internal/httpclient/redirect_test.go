@@ -0,0 +1,166 @@
+package httpclient
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSend307RedirectPreservesMethodAndBody(t *testing.T) {
+	var gotMethod, gotBody string
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotMethod = r.Method
+		body, _ := io.ReadAll(r.Body)
+		gotBody = string(body)
+	}))
+	defer target.Close()
+
+	redirector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, target.URL, http.StatusTemporaryRedirect)
+	}))
+	defer redirector.Close()
+
+	client := NewClient()
+	_, err := client.Send(context.Background(), RequestConfig{
+		Method: "POST",
+		URL:    redirector.URL,
+		Body:   "hello",
+	})
+	if err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if gotMethod != "POST" {
+		t.Errorf("method after 307 = %q, want POST", gotMethod)
+	}
+	if gotBody != "hello" {
+		t.Errorf("body after 307 = %q, want %q", gotBody, "hello")
+	}
+}
+
+func TestSend302RedirectDowngradesPostToGet(t *testing.T) {
+	var gotMethod string
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotMethod = r.Method
+	}))
+	defer target.Close()
+
+	redirector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, target.URL, http.StatusFound)
+	}))
+	defer redirector.Close()
+
+	client := NewClient()
+	_, err := client.Send(context.Background(), RequestConfig{
+		Method: "POST",
+		URL:    redirector.URL,
+		Body:   "hello",
+	})
+	if err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if gotMethod != "GET" {
+		t.Errorf("method after 302 = %q, want GET", gotMethod)
+	}
+}
+
+func TestSend308RedirectReplaysBodyFilePath(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "upload.bin")
+	if err := os.WriteFile(path, []byte("file body"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var gotMethod, gotBody string
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotMethod = r.Method
+		body, _ := io.ReadAll(r.Body)
+		gotBody = string(body)
+	}))
+	defer target.Close()
+
+	redirector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, target.URL, http.StatusPermanentRedirect)
+	}))
+	defer redirector.Close()
+
+	client := NewClient()
+	_, err := client.Send(context.Background(), RequestConfig{
+		Method:       "PUT",
+		URL:          redirector.URL,
+		BodyFilePath: path,
+	})
+	if err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if gotMethod != "PUT" {
+		t.Errorf("method after 308 = %q, want PUT", gotMethod)
+	}
+	if gotBody != "file body" {
+		t.Errorf("body after 308 = %q, want %q", gotBody, "file body")
+	}
+}
+
+// countingReader counts how many bytes it has served, so the test can
+// assert cappedDrainBody stopped reading well short of the source size.
+type countingReader struct {
+	r    io.Reader
+	read int
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.read += n
+	return n, err
+}
+
+func TestCappedDrainBodyCloseStopsAtCap(t *testing.T) {
+	src := &countingReader{r: bytes.NewReader(make([]byte, 10*redirectBodyDrainCap))}
+	body := &cappedDrainBody{ReadCloser: io.NopCloser(src)}
+
+	if err := body.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if src.read > redirectBodyDrainCap {
+		t.Errorf("Close drained %d bytes, want at most %d", src.read, redirectBodyDrainCap)
+	}
+}
+
+func TestSendRedirectWithLargeIntermediateBodyDrainsBounded(t *testing.T) {
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("final"))
+	}))
+	defer target.Close()
+
+	redirector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// An intermediate hop's body that net/http's redirect following
+		// never reads - large enough that buffering it in full would be
+		// the bug this test guards against.
+		w.Header().Set("Location", target.URL)
+		w.WriteHeader(http.StatusFound)
+		io.Copy(w, io.LimitReader(neverEndingZeroes{}, 8*redirectBodyDrainCap))
+	}))
+	defer redirector.Close()
+
+	client := NewClient()
+	resp, err := client.Send(context.Background(), RequestConfig{Method: "GET", URL: redirector.URL})
+	if err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if !strings.Contains(resp.Body, "final") {
+		t.Errorf("Body = %q, want it to contain the final response's body", resp.Body)
+	}
+}
+
+type neverEndingZeroes struct{}
+
+func (neverEndingZeroes) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = 0
+	}
+	return len(p), nil
+}
@@ -0,0 +1,34 @@
+package httpclient
+
+import "context"
+
+// RepeatResult pairs one SendRepeat iteration's response with the
+// fingerprint it actually used.
+type RepeatResult struct {
+	Index    int
+	Response *ResponseData
+	Err      error
+	TlsUsed  *TlsConfig
+}
+
+// SendRepeat sends cfg count times, useful for benchmarking or scraping. If
+// rotation is non-empty, each iteration round-robins through it for its TLS
+// fingerprint instead of using cfg.Tls/the client default, varying the
+// fingerprint presented across iterations.
+func (c *Client) SendRepeat(ctx context.Context, cfg RequestConfig, rotation []*TlsConfig, count int) []RepeatResult {
+	results := make([]RepeatResult, 0, count)
+	for i := 0; i < count; i++ {
+		iterCfg := cfg
+		if len(rotation) > 0 {
+			iterCfg.Tls = rotation[i%len(rotation)]
+		}
+		resp, err := c.Send(ctx, iterCfg)
+		results = append(results, RepeatResult{
+			Index:    i,
+			Response: resp,
+			Err:      err,
+			TlsUsed:  iterCfg.Tls,
+		})
+	}
+	return results
+}
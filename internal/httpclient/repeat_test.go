@@ -0,0 +1,41 @@
+package httpclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"httpcall/internal/tlsfp"
+)
+
+func TestSendRepeatRotatesFingerprint(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	rotation := []*TlsConfig{
+		{JA3: tlsfp.Presets["chrome120"].JA3},
+		{JA3: tlsfp.Presets["firefox120"].JA3},
+	}
+
+	client := NewClient()
+	results := client.SendRepeat(context.Background(), RequestConfig{Method: "GET", URL: srv.URL}, rotation, 4)
+
+	if len(results) != 4 {
+		t.Fatalf("got %d results, want 4", len(results))
+	}
+	for i, r := range results {
+		if r.Err != nil {
+			t.Fatalf("iteration %d failed: %v", i, r.Err)
+		}
+		want := rotation[i%len(rotation)].JA3
+		if r.TlsUsed.JA3 != want {
+			t.Errorf("iteration %d used JA3 %q, want %q", i, r.TlsUsed.JA3, want)
+		}
+	}
+	if results[0].Response.JA3Hash == results[1].Response.JA3Hash {
+		t.Error("expected JA3 hash to vary between rotation entries")
+	}
+}
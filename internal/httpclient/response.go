@@ -0,0 +1,117 @@
+// Package httpclient implements HttpCall's request/response pipeline: taking
+// a user-built request configuration, executing it over a stdlib
+// http.Transport that dials through the package's own TLS dialer, and
+// turning the result into UI-friendly data.
+package httpclient
+
+import (
+	"net/http"
+	"strings"
+)
+
+// ResponseCookie is a single Set-Cookie response header, parsed into its
+// individual attributes so the UI can display it and let users promote it
+// into the next request without re-parsing raw header text.
+type ResponseCookie struct {
+	Name     string `json:"name"`
+	Value    string `json:"value"`
+	Domain   string `json:"domain"`
+	Path     string `json:"path"`
+	Expires  string `json:"expires"`
+	Secure   bool   `json:"secure"`
+	HttpOnly bool   `json:"httpOnly"`
+	SameSite string `json:"sameSite"`
+}
+
+// ResponseData is the parsed, UI-friendly representation of an HTTP response.
+type ResponseData struct {
+	StatusCode int               `json:"statusCode"`
+	Status     string            `json:"status"`
+	Headers    map[string]string `json:"headers"`
+	Cookies    []ResponseCookie  `json:"cookies"`
+	Body       string            `json:"body"`
+	// HasBody reports whether the response actually carried any body bytes
+	// (true for a legitimately empty 200, same as any other response with a
+	// zero-length body, including 204/304/HEAD) - a separate field from Body
+	// so callers don't need to treat "" as ambiguous between the two.
+	HasBody bool `json:"hasBody"`
+	// JA3Hash is the MD5 hash of the JA3 fingerprint the request was sent
+	// with, when one was configured. Lets callers confirm which fingerprint
+	// a given response actually used.
+	JA3Hash string `json:"ja3Hash,omitempty"`
+	// TlsInfo reports what the TLS handshake for this request actually did.
+	// Nil for plain HTTP requests, since dialTLS never runs for those.
+	TlsInfo *TlsHandshakeInfo `json:"tlsInfo,omitempty"`
+	// Timing breaks the request down into DNS/TCP/TLS/TTFB/Total phases.
+	Timing Timing `json:"timing"`
+	// GRPCStatus is the grpc-status/grpc-message parsed from the response
+	// body's gRPC-Web trailer frame, when RequestConfig.GRPCWeb was set. Nil
+	// for ordinary requests, or if the body had no trailer frame.
+	GRPCStatus *GRPCWebStatus `json:"grpcStatus,omitempty"`
+	// RedirectHops records each redirect hop followed to reach this
+	// response, in order, including which cookies the jar attached to each
+	// one. Empty if the request wasn't redirected.
+	RedirectHops []RedirectHop `json:"redirectHops,omitempty"`
+	// Incomplete reports whether Body was cut short - a read error, GOAWAY,
+	// RST_STREAM, or a deadline firing mid-body - rather than the body
+	// finishing normally. Callers should treat Body as partial, not as a
+	// truncated-but-otherwise-trustworthy response, when this is true.
+	Incomplete bool `json:"incomplete,omitempty"`
+	// TruncationReason is the read error that cut Body short, set only
+	// when Incomplete is true.
+	TruncationReason string `json:"truncationReason,omitempty"`
+}
+
+// parseResponse builds a ResponseData from an already-read *http.Response.
+// Multi-value headers are joined with "\n" to keep the header map flat.
+func parseResponse(resp *http.Response, body []byte) (*ResponseData, error) {
+	headers := make(map[string]string, len(resp.Header))
+	for k, values := range resp.Header {
+		headers[k] = strings.Join(values, "\n")
+	}
+
+	return &ResponseData{
+		StatusCode: resp.StatusCode,
+		Status:     resp.Status,
+		Headers:    headers,
+		Cookies:    parseResponseCookies(resp),
+		Body:       string(body),
+		HasBody:    len(body) > 0,
+	}, nil
+}
+
+// parseResponseCookies turns resp.Cookies() into the structured form the UI
+// needs, including attributes the stdlib's Set-Cookie header string hides.
+func parseResponseCookies(resp *http.Response) []ResponseCookie {
+	cookies := resp.Cookies()
+	result := make([]ResponseCookie, 0, len(cookies))
+	for _, c := range cookies {
+		result = append(result, newResponseCookie(c))
+	}
+	return result
+}
+
+// newResponseCookie converts a *http.Cookie into the structured form the UI
+// needs, including attributes the stdlib's Set-Cookie header string hides.
+func newResponseCookie(c *http.Cookie) ResponseCookie {
+	rc := ResponseCookie{
+		Name:     c.Name,
+		Value:    c.Value,
+		Domain:   c.Domain,
+		Path:     c.Path,
+		Secure:   c.Secure,
+		HttpOnly: c.HttpOnly,
+	}
+	if !c.Expires.IsZero() {
+		rc.Expires = c.Expires.UTC().Format(http.TimeFormat)
+	}
+	switch c.SameSite {
+	case http.SameSiteLaxMode:
+		rc.SameSite = "Lax"
+	case http.SameSiteStrictMode:
+		rc.SameSite = "Strict"
+	case http.SameSiteNoneMode:
+		rc.SameSite = "None"
+	}
+	return rc
+}
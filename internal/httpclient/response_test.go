@@ -0,0 +1,57 @@
+package httpclient
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestParseResponseCookiesMultiple(t *testing.T) {
+	header := http.Header{}
+	header.Add("Set-Cookie", "session=abc123; Domain=example.com; Path=/; Secure; HttpOnly; SameSite=Lax")
+	header.Add("Set-Cookie", "theme=dark; Path=/app")
+	resp := &http.Response{Header: header}
+
+	data, err := parseResponse(resp, nil)
+	if err != nil {
+		t.Fatalf("parseResponse: %v", err)
+	}
+	if len(data.Cookies) != 2 {
+		t.Fatalf("got %d cookies, want 2", len(data.Cookies))
+	}
+
+	session := data.Cookies[0]
+	if session.Name != "session" || session.Value != "abc123" {
+		t.Errorf("session cookie = %+v", session)
+	}
+	if session.Domain != "example.com" || session.Path != "/" {
+		t.Errorf("session cookie attrs = %+v", session)
+	}
+	if !session.Secure || !session.HttpOnly || session.SameSite != "Lax" {
+		t.Errorf("session cookie flags = %+v", session)
+	}
+
+	theme := data.Cookies[1]
+	if theme.Name != "theme" || theme.Value != "dark" || theme.Path != "/app" {
+		t.Errorf("theme cookie = %+v", theme)
+	}
+}
+
+func TestParseResponseHasBodyTrueForNonEmptyBody(t *testing.T) {
+	data, err := parseResponse(&http.Response{Header: http.Header{}}, []byte("hello"))
+	if err != nil {
+		t.Fatalf("parseResponse: %v", err)
+	}
+	if !data.HasBody {
+		t.Error("HasBody = false, want true for a non-empty body")
+	}
+}
+
+func TestParseResponseHasBodyFalseForEmptyBody(t *testing.T) {
+	data, err := parseResponse(&http.Response{Header: http.Header{}}, nil)
+	if err != nil {
+		t.Fatalf("parseResponse: %v", err)
+	}
+	if data.HasBody {
+		t.Error("HasBody = true, want false for an empty body")
+	}
+}
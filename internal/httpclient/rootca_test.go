@@ -0,0 +1,119 @@
+package httpclient
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// generateCASignedServerCert builds a self-signed CA and a leaf certificate
+// for "localhost" signed by it, returning the leaf as a tls.Certificate
+// ready for a test server and the CA's own cert PEM-encoded for
+// TlsConfig.RootCAPEM.
+func generateCASignedServerCert(t *testing.T) (leaf tls.Certificate, caPEM string) {
+	t.Helper()
+
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	caTemplate := x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test-ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, &caTemplate, &caTemplate, &caKey.PublicKey, caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	caCert, err := x509.ParseCertificate(caDER)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	leafKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	leafTemplate := x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		DNSNames:     []string{"localhost"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	leafDER, err := x509.CreateCertificate(rand.Reader, &leafTemplate, caCert, &leafKey.PublicKey, caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	leaf = tls.Certificate{Certificate: [][]byte{leafDER, caDER}, PrivateKey: leafKey}
+	caPEM = string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caDER}))
+	return leaf, caPEM
+}
+
+func TestDialTLSWithCustomRootCAVerifiesServerSignedByIt(t *testing.T) {
+	leaf, caPEM := generateCASignedServerCert(t)
+
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	srv.TLS = &tls.Config{Certificates: []tls.Certificate{leaf}}
+	srv.StartTLS()
+	defer srv.Close()
+
+	url := strings.Replace(srv.URL, "127.0.0.1", "localhost", 1)
+
+	client := NewClient()
+
+	if _, err := client.Send(context.Background(), RequestConfig{Method: "GET", URL: url}); err == nil {
+		t.Fatal("expected the system root pool alone to reject this server's cert")
+	}
+
+	resp, err := client.Send(context.Background(), RequestConfig{
+		Method: "GET",
+		URL:    url,
+		Tls:    &TlsConfig{RootCAPEM: caPEM},
+	})
+	if err != nil {
+		t.Fatalf("Send with RootCAPEM: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("StatusCode = %d, want 200", resp.StatusCode)
+	}
+}
+
+func TestDialTLSWithMalformedRootCAPEMReturnsClearError(t *testing.T) {
+	client := NewClient()
+	ctx := withTlsConfig(context.Background(), &TlsConfig{RootCAPEM: "not a cert"})
+
+	_, err := client.dialTLS(ctx, "tcp", "127.0.0.1:1")
+	if err == nil {
+		t.Fatal("expected an error for a malformed RootCAPEM")
+	}
+
+	tlsErr, ok := err.(*TlsHandshakeError)
+	if !ok {
+		t.Fatalf("error = %v (%T), want *TlsHandshakeError", err, err)
+	}
+	if tlsErr.Category != TlsErrorRootCAInvalid {
+		t.Errorf("Category = %q, want %q", tlsErr.Category, TlsErrorRootCAInvalid)
+	}
+}
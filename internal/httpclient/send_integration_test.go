@@ -0,0 +1,35 @@
+package httpclient
+
+import (
+	"context"
+	"crypto/tls"
+	"testing"
+
+	"httpcall/internal/testserver"
+)
+
+// TestSendAgainstEmbeddedServer exercises Client.Send end-to-end against the
+// in-process test server instead of a live host, so it runs offline and
+// deterministically in CI.
+func TestSendAgainstEmbeddedServer(t *testing.T) {
+	srv := testserver.New()
+	defer srv.Close()
+
+	client := NewClient()
+
+	resp, err := client.Send(context.Background(), RequestConfig{Method: "GET", URL: srv.URL})
+	if err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if resp.StatusCode != 200 {
+		t.Errorf("StatusCode = %d, want 200", resp.StatusCode)
+	}
+
+	recorded := srv.Requests()
+	if len(recorded) != 1 {
+		t.Fatalf("got %d recorded requests, want 1", len(recorded))
+	}
+	if recorded[0].TLSVersion != tls.VersionTLS13 {
+		t.Errorf("TLSVersion = %#x, want TLS 1.3", recorded[0].TLSVersion)
+	}
+}
@@ -0,0 +1,122 @@
+package httpclient
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"httpcall/internal/tlsfp"
+)
+
+// SendStream behaves like Send, but calls onChunk with each piece of the
+// response body as it arrives instead of buffering the whole thing before
+// returning - the right choice for large responses a UI wants to show
+// download progress on without the memory spike (and UI stall) of waiting
+// for the full body first. Like Download, it sends cfg.Body as-is (not
+// BodyIsBase64/FormData/BodyFilePath) and rejects compressed responses,
+// since a still-compressed chunk isn't independently useful to a streaming
+// caller. The returned ResponseData's Body is always empty; callers get body
+// bytes through onChunk. resp.Body.Close() runs via defer regardless of how
+// the loop exits, so the connection is always released - net/http closes
+// rather than pools one whose body wasn't fully drained.
+func (c *Client) SendStream(ctx context.Context, cfg RequestConfig, onChunk func([]byte) error) (*ResponseData, error) {
+	if c.rateLimiter != nil {
+		if err := c.rateLimiter.Wait(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	limits := resolveLimits(cfg.Limits)
+	if cfg.MaxResponseBytes > 0 {
+		limits.MaxResponseBodyBytes = cfg.MaxResponseBytes
+	}
+	if err := checkRequestBodySize(cfg.Body, limits); err != nil {
+		return nil, err
+	}
+
+	ctx = withTlsConfig(ctx, c.effectiveTls(cfg.Tls))
+	tlsInfo := &TlsHandshakeInfo{}
+	ctx = withTlsInfoHolder(ctx, tlsInfo)
+	tracker := newTimingTracker(nil)
+	ctx = tracker.withContext(ctx)
+	var hops []RedirectHop
+	ctx = withRedirectHops(ctx, &hops)
+
+	req, err := http.NewRequestWithContext(ctx, cfg.Method, cfg.URL, strings.NewReader(cfg.Body))
+	if err != nil {
+		return nil, err
+	}
+	for _, h := range cfg.Headers {
+		req.Header.Add(h.Name, h.Value)
+	}
+	applyHostHeaderOverride(req)
+	suppressHeaders(req, cfg.SuppressHeaders)
+
+	for _, interceptor := range c.interceptors {
+		interceptor.BeforeRequest(req)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	for _, interceptor := range c.interceptors {
+		interceptor.AfterResponse(resp)
+	}
+
+	if err := checkResponseHeaderSize(resp.Header, limits); err != nil {
+		return nil, err
+	}
+	if enc := resp.Header.Get("Content-Encoding"); enc != "" {
+		return nil, fmt.Errorf("httpclient: SendStream doesn't support compressed responses (Content-Encoding: %s); use Send instead", enc)
+	}
+
+	chunkSize := cfg.ChunkSizeBytes
+	if chunkSize <= 0 {
+		chunkSize = defaultDownloadChunkBytes
+	}
+
+	var written int64
+	buf := make([]byte, chunkSize)
+	for {
+		n, readErr := resp.Body.Read(buf)
+		if n > 0 {
+			written += int64(n)
+			if limits.MaxResponseBodyBytes > 0 && written > limits.MaxResponseBodyBytes {
+				return nil, &LimitExceededError{Reason: "response_body"}
+			}
+			if onChunk != nil {
+				chunk := make([]byte, n)
+				copy(chunk, buf[:n])
+				if err := onChunk(chunk); err != nil {
+					return nil, err
+				}
+			}
+		}
+		if readErr == io.EOF {
+			break
+		}
+		if readErr != nil {
+			return nil, readErr
+		}
+	}
+
+	data, err := parseResponse(resp, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if tls := c.effectiveTls(cfg.Tls); tls != nil && tls.JA3 != "" {
+		data.JA3Hash = tlsfp.CalculateJA3Hash(tls.JA3)
+	}
+	if tlsInfo.Version != "" {
+		data.TlsInfo = tlsInfo
+	}
+	data.Timing = tracker.finish()
+	data.RedirectHops = hops
+	return data, nil
+}
@@ -0,0 +1,90 @@
+package httpclient
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSendStreamDeliversChunksAndLeavesBodyEmpty(t *testing.T) {
+	payload := bytes.Repeat([]byte("x"), 5*1024*1024) // 5MB
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(payload)
+	}))
+	defer srv.Close()
+
+	var received bytes.Buffer
+	var chunkCalls int
+	client := NewClient()
+	resp, err := client.SendStream(context.Background(), RequestConfig{Method: "GET", URL: srv.URL}, func(chunk []byte) error {
+		chunkCalls++
+		received.Write(chunk)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("SendStream: %v", err)
+	}
+	if chunkCalls == 0 {
+		t.Error("expected at least one chunk callback")
+	}
+	if received.Len() != len(payload) {
+		t.Fatalf("received %d bytes via chunks, want %d", received.Len(), len(payload))
+	}
+	if !bytes.Equal(received.Bytes(), payload) {
+		t.Error("chunk bytes don't match server body")
+	}
+	if resp.Body != "" {
+		t.Errorf("ResponseData.Body = %q, want empty (body delivered via onChunk)", resp.Body)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("StatusCode = %d, want 200", resp.StatusCode)
+	}
+}
+
+func TestSendStreamRejectsCompressedResponses(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Encoding", "gzip")
+		w.Write([]byte("not actually gzipped, doesn't matter"))
+	}))
+	defer srv.Close()
+
+	client := NewClient()
+	_, err := client.SendStream(context.Background(), RequestConfig{Method: "GET", URL: srv.URL}, func([]byte) error { return nil })
+	if err == nil {
+		t.Fatal("expected an error for a compressed response, got nil")
+	}
+}
+
+func TestSendStreamOnChunkErrorAbortsAndPropagates(t *testing.T) {
+	payload := bytes.Repeat([]byte("y"), 1024*1024)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(payload)
+	}))
+	defer srv.Close()
+
+	abandon := errAbandoned
+	client := NewClient()
+	_, err := client.SendStream(context.Background(), RequestConfig{Method: "GET", URL: srv.URL}, func([]byte) error {
+		return abandon
+	})
+	if err != abandon {
+		t.Fatalf("SendStream err = %v, want %v", err, abandon)
+	}
+}
+
+func TestSendStreamEnforcesMaxResponseBodyBytes(t *testing.T) {
+	payload := bytes.Repeat([]byte("z"), 1024)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(payload)
+	}))
+	defer srv.Close()
+
+	client := NewClient()
+	limits := Limits{MaxResponseBodyBytes: 100}
+	_, err := client.SendStream(context.Background(), RequestConfig{Method: "GET", URL: srv.URL, Limits: &limits}, func([]byte) error { return nil })
+	if _, ok := err.(*LimitExceededError); !ok {
+		t.Fatalf("err = %v, want *LimitExceededError", err)
+	}
+}
@@ -0,0 +1,41 @@
+package httpclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestDialTLSPopulatesServerCert(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+
+	client := NewClient()
+	holder := &TlsHandshakeInfo{}
+	ctx := withTlsInfoHolder(context.Background(), holder)
+
+	conn, err := client.dialTLS(ctx, "tcp", srv.Listener.Addr().String())
+	if err != nil {
+		t.Fatalf("dialTLS: %v", err)
+	}
+	defer conn.Close()
+
+	if holder.ServerCert == nil {
+		t.Fatal("ServerCert is nil, want populated")
+	}
+	wantSANs := srv.Certificate().DNSNames
+	if len(holder.ServerCert.SANs) != len(wantSANs) {
+		t.Errorf("SANs = %v, want %v", holder.ServerCert.SANs, wantSANs)
+	} else {
+		for i := range wantSANs {
+			if holder.ServerCert.SANs[i] != wantSANs[i] {
+				t.Errorf("SANs = %v, want %v", holder.ServerCert.SANs, wantSANs)
+				break
+			}
+		}
+	}
+	if holder.ServerCert.NotAfter.Before(holder.ServerCert.NotBefore) {
+		t.Errorf("NotAfter (%v) is before NotBefore (%v)", holder.ServerCert.NotAfter, holder.ServerCert.NotBefore)
+	}
+}
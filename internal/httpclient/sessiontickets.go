@@ -0,0 +1,153 @@
+package httpclient
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+// sessionTicketRecord is one host's resumable session, in the serializable
+// form ClientSessionState.ResumptionState/NewResumptionState round-trip
+// through.
+type sessionTicketRecord struct {
+	Ticket []byte `json:"ticket"`
+	State  []byte `json:"state"` // tls.SessionState.Bytes()
+}
+
+// FileSessionTicketCache is a tls.ClientSessionCache that persists its
+// entries to an AES-GCM-encrypted file, so a ticket obtained in one process
+// is still available to resume from in the next - the "returning visitor"
+// fingerprint a fresh process can't otherwise produce, since a brand new
+// TLS connection with no prior session always performs a full handshake.
+//
+// Encryption is required (not optional) because a session ticket is
+// effectively a credential: anyone holding it can resume the session it
+// names.
+type FileSessionTicketCache struct {
+	path string
+	aead cipher.AEAD
+
+	mu      sync.Mutex
+	entries map[string]sessionTicketRecord
+}
+
+// NewFileSessionTicketCache opens (or creates) a FileSessionTicketCache
+// backed by path, encrypting its contents with key (must be 16, 24, or 32
+// bytes - an AES-128/192/256 key). A missing file starts with an empty
+// cache rather than erroring, the same as a fresh install with no prior
+// tickets; any other read or decryption failure is returned, since a
+// corrupt or tampered ticket file shouldn't be silently discarded.
+func NewFileSessionTicketCache(path string, key []byte) (*FileSessionTicketCache, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("httpclient: session ticket cache key: %w", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &FileSessionTicketCache{path: path, aead: aead, entries: make(map[string]sessionTicketRecord)}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return c, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return c, nil
+	}
+	if err := c.decryptInto(data); err != nil {
+		return nil, fmt.Errorf("httpclient: session ticket cache %s: %w", path, err)
+	}
+	return c, nil
+}
+
+func (c *FileSessionTicketCache) decryptInto(data []byte) error {
+	nonceSize := c.aead.NonceSize()
+	if len(data) < nonceSize {
+		return fmt.Errorf("ciphertext shorter than nonce")
+	}
+	nonce, ciphertext := data[:nonceSize], data[nonceSize:]
+	plaintext, err := c.aead.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return err
+	}
+	var entries map[string]sessionTicketRecord
+	if err := json.Unmarshal(plaintext, &entries); err != nil {
+		return err
+	}
+	c.entries = entries
+	return nil
+}
+
+// Get implements tls.ClientSessionCache.
+func (c *FileSessionTicketCache) Get(sessionKey string) (*tls.ClientSessionState, bool) {
+	c.mu.Lock()
+	record, ok := c.entries[sessionKey]
+	c.mu.Unlock()
+	if !ok {
+		return nil, false
+	}
+
+	state, err := tls.ParseSessionState(record.State)
+	if err != nil {
+		return nil, false
+	}
+	cs, err := tls.NewResumptionState(record.Ticket, state)
+	if err != nil {
+		return nil, false
+	}
+	return cs, true
+}
+
+// Put implements tls.ClientSessionCache. A nil cs (crypto/tls's signal to
+// evict an entry it no longer considers usable) removes sessionKey instead
+// of storing it.
+func (c *FileSessionTicketCache) Put(sessionKey string, cs *tls.ClientSessionState) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if cs == nil {
+		delete(c.entries, sessionKey)
+		return
+	}
+	ticket, state, err := cs.ResumptionState()
+	if err != nil {
+		return
+	}
+	stateBytes, err := state.Bytes()
+	if err != nil {
+		return
+	}
+	c.entries[sessionKey] = sessionTicketRecord{Ticket: ticket, State: stateBytes}
+}
+
+// Save encrypts and writes c's current entries to its file, overwriting
+// whatever was there before. Callers decide when to call this (e.g. on app
+// shutdown) - Put doesn't write through on every call, since a session
+// ticket arrives on essentially every TLS 1.3 handshake and disk I/O on
+// that path would be wasteful.
+func (c *FileSessionTicketCache) Save() error {
+	c.mu.Lock()
+	plaintext, err := json.Marshal(c.entries)
+	c.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	nonce := make([]byte, c.aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return err
+	}
+	ciphertext := c.aead.Seal(nonce, nonce, plaintext, nil)
+	return os.WriteFile(c.path, ciphertext, 0600)
+}
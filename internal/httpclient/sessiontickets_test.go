@@ -0,0 +1,121 @@
+package httpclient
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/tls"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// primeSessionTicket performs one real TLS handshake against addr using
+// cache, then reads one HTTP response over it - TLS 1.3's session ticket
+// arrives as a post-handshake message the client only processes while
+// reading, so HandshakeContext returning isn't enough to populate cache.
+func primeSessionTicket(t *testing.T, addr string, cache tls.ClientSessionCache) {
+	t.Helper()
+	rawConn, err := net.Dial("tcp", addr)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	defer rawConn.Close()
+
+	conn := tls.Client(rawConn, &tls.Config{InsecureSkipVerify: true, ClientSessionCache: cache})
+	if err := conn.Handshake(); err != nil {
+		t.Fatalf("handshake: %v", err)
+	}
+
+	req, _ := http.NewRequest("GET", "/", nil)
+	if err := req.Write(conn); err != nil {
+		t.Fatalf("write request: %v", err)
+	}
+	resp, err := http.ReadResponse(bufio.NewReader(conn), req)
+	if err != nil {
+		t.Fatalf("read response: %v", err)
+	}
+	resp.Body.Close()
+}
+
+// dialChecksResumption dials addr fresh using cache and reports whether the
+// handshake resumed a prior session.
+func dialChecksResumption(t *testing.T, addr string, cache tls.ClientSessionCache) bool {
+	t.Helper()
+	rawConn, err := net.Dial("tcp", addr)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	defer rawConn.Close()
+
+	conn := tls.Client(rawConn, &tls.Config{InsecureSkipVerify: true, ClientSessionCache: cache})
+	if err := conn.Handshake(); err != nil {
+		t.Fatalf("handshake: %v", err)
+	}
+	return conn.ConnectionState().DidResume
+}
+
+func TestFileSessionTicketCachePersistsTicketAcrossRestartAndResumes(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+	addr := strings.TrimPrefix(srv.URL, "https://")
+
+	path := filepath.Join(t.TempDir(), "tickets.enc")
+	key := bytes.Repeat([]byte{0x7a}, 32)
+
+	cacheA, err := NewFileSessionTicketCache(path, key)
+	if err != nil {
+		t.Fatalf("NewFileSessionTicketCache: %v", err)
+	}
+	primeSessionTicket(t, addr, cacheA)
+	if err := cacheA.Save(); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	// A fresh cache instance loaded from the same encrypted file simulates
+	// a new process starting up after the old one saved and exited.
+	cacheB, err := NewFileSessionTicketCache(path, key)
+	if err != nil {
+		t.Fatalf("NewFileSessionTicketCache (reload): %v", err)
+	}
+	if !dialChecksResumption(t, addr, cacheB) {
+		t.Error("handshake using a ticket restored from disk did not resume the session")
+	}
+}
+
+func TestFileSessionTicketCacheRejectsWrongKeyOnReload(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+	addr := strings.TrimPrefix(srv.URL, "https://")
+
+	path := filepath.Join(t.TempDir(), "tickets.enc")
+	cacheA, err := NewFileSessionTicketCache(path, bytes.Repeat([]byte{0x01}, 32))
+	if err != nil {
+		t.Fatalf("NewFileSessionTicketCache: %v", err)
+	}
+	primeSessionTicket(t, addr, cacheA)
+	if err := cacheA.Save(); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	if _, err := NewFileSessionTicketCache(path, bytes.Repeat([]byte{0x02}, 32)); err == nil {
+		t.Error("expected an error decrypting the ticket file with the wrong key")
+	}
+}
+
+func TestFileSessionTicketCacheStartsEmptyWhenFileMissing(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "does-not-exist.enc")
+	cache, err := NewFileSessionTicketCache(path, bytes.Repeat([]byte{0x03}, 32))
+	if err != nil {
+		t.Fatalf("NewFileSessionTicketCache: %v", err)
+	}
+	if _, ok := cache.Get("example.com"); ok {
+		t.Error("Get found an entry in a cache that started from a missing file")
+	}
+}
@@ -0,0 +1,212 @@
+package httpclient
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// HMACSigningParams configures a preview of HttpCall's simple HMAC signing
+// scheme: a newline-joined canonical string of method, URL, timestamp, and
+// body, signed with HMAC-SHA256 under Secret.
+type HMACSigningParams struct {
+	Method    string
+	URL       string
+	Body      string
+	Timestamp string
+	Secret    string
+}
+
+// HMACSigningPreview is the intermediate artifact PreviewHMACSignature
+// computes, returned without sending a request so a mismatched signature
+// can be debugged against exactly what was signed.
+type HMACSigningPreview struct {
+	CanonicalString string `json:"canonicalString"`
+	Signature       string `json:"signature"`
+}
+
+// PreviewHMACSignature computes p's canonical string and HMAC-SHA256
+// signature (hex-encoded) without sending a request.
+func PreviewHMACSignature(p HMACSigningParams) HMACSigningPreview {
+	canonical := strings.Join([]string{p.Method, p.URL, p.Timestamp, p.Body}, "\n")
+	return HMACSigningPreview{
+		CanonicalString: canonical,
+		Signature:       hex.EncodeToString(hmacSHA256([]byte(p.Secret), canonical)),
+	}
+}
+
+// OAuth1SigningParams configures an OAuth 1.0a HMAC-SHA1 signature preview
+// (RFC 5849 §3.4). Params should hold every oauth_* parameter plus any
+// query/body parameters the request signs - this preview doesn't support a
+// parameter repeated with multiple values, matching most OAuth1 clients in
+// practice.
+type OAuth1SigningParams struct {
+	Method         string
+	URL            string // base URI, normalized and with no query string
+	Params         map[string]string
+	ConsumerSecret string
+	TokenSecret    string
+}
+
+// OAuth1SigningPreview is the intermediate artifact PreviewOAuth1Signature
+// computes, returned without sending a request.
+type OAuth1SigningPreview struct {
+	BaseString string `json:"baseString"`
+	Signature  string `json:"signature"`
+}
+
+// PreviewOAuth1Signature computes p's signature base string (RFC 5849 §3.4.1)
+// and HMAC-SHA1 signature (base64-encoded, per §3.4.2) without sending a
+// request.
+func PreviewOAuth1Signature(p OAuth1SigningParams) OAuth1SigningPreview {
+	keys := make([]string, 0, len(p.Params))
+	for k := range p.Params {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, k := range keys {
+		pairs = append(pairs, oauthEncode(k)+"="+oauthEncode(p.Params[k]))
+	}
+	paramString := strings.Join(pairs, "&")
+	baseString := strings.Join([]string{
+		strings.ToUpper(p.Method),
+		oauthEncode(p.URL),
+		oauthEncode(paramString),
+	}, "&")
+
+	signingKey := oauthEncode(p.ConsumerSecret) + "&" + oauthEncode(p.TokenSecret)
+	mac := hmac.New(sha1.New, []byte(signingKey))
+	mac.Write([]byte(baseString))
+
+	return OAuth1SigningPreview{
+		BaseString: baseString,
+		Signature:  base64.StdEncoding.EncodeToString(mac.Sum(nil)),
+	}
+}
+
+// oauthEncode percent-encodes s per RFC 5849 §3.6: letters, digits, "-",
+// ".", "_", and "~" pass through unescaped; everything else (including "/"
+// and "+") is escaped as %XX, uppercase hex.
+func oauthEncode(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if isOAuthUnreserved(c) {
+			b.WriteByte(c)
+		} else {
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+func isOAuthUnreserved(c byte) bool {
+	return (c >= 'A' && c <= 'Z') || (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9') ||
+		c == '-' || c == '.' || c == '_' || c == '~'
+}
+
+// SigV4SigningParams configures an AWS Signature Version 4 preview, as
+// described at
+// https://docs.aws.amazon.com/general/latest/gr/sigv4-create-canonical-request.html.
+// URI and Query are taken as already-canonical (URI-encoded path and
+// sorted, encoded query string); Headers need only contain the headers the
+// caller intends to sign.
+type SigV4SigningParams struct {
+	Method    string
+	URI       string
+	Query     string
+	Headers   map[string]string
+	Body      string
+	AmzDate   string // YYYYMMDD'T'HHMMSS'Z'
+	Region    string
+	Service   string
+	SecretKey string
+}
+
+// SigV4SigningPreview is the intermediate artifact PreviewSigV4Signature
+// computes - the three values AWS itself recommends checking when a SigV4
+// signature doesn't match - returned without sending a request.
+type SigV4SigningPreview struct {
+	CanonicalRequest string `json:"canonicalRequest"`
+	StringToSign     string `json:"stringToSign"`
+	Signature        string `json:"signature"`
+}
+
+// PreviewSigV4Signature computes p's canonical request, string-to-sign, and
+// signature without sending a request.
+func PreviewSigV4Signature(p SigV4SigningParams) SigV4SigningPreview {
+	lower := make(map[string]string, len(p.Headers))
+	names := make([]string, 0, len(p.Headers))
+	for k, v := range p.Headers {
+		name := strings.ToLower(k)
+		lower[name] = v
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name)
+		canonicalHeaders.WriteByte(':')
+		canonicalHeaders.WriteString(strings.TrimSpace(lower[name]))
+		canonicalHeaders.WriteByte('\n')
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		p.Method,
+		p.URI,
+		p.Query,
+		canonicalHeaders.String(),
+		signedHeaders,
+		sha256Hex([]byte(p.Body)),
+	}, "\n")
+
+	dateStamp := p.AmzDate
+	if len(dateStamp) >= 8 {
+		dateStamp = dateStamp[:8]
+	}
+	credentialScope := fmt.Sprintf("%s/%s/%s/aws4_request", dateStamp, p.Region, p.Service)
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		p.AmzDate,
+		credentialScope,
+		sha256Hex([]byte(canonicalRequest)),
+	}, "\n")
+
+	signingKey := sigV4SigningKey(p.SecretKey, dateStamp, p.Region, p.Service)
+	signature := hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+
+	return SigV4SigningPreview{
+		CanonicalRequest: canonicalRequest,
+		StringToSign:     stringToSign,
+		Signature:        signature,
+	}
+}
+
+// sigV4SigningKey derives the request-scoped signing key through SigV4's
+// four-step HMAC chain (date -> region -> service -> aws4_request).
+func sigV4SigningKey(secretKey, dateStamp, region, service string) []byte {
+	kDate := hmacSHA256([]byte("AWS4"+secretKey), dateStamp)
+	kRegion := hmacSHA256(kDate, region)
+	kService := hmacSHA256(kRegion, service)
+	return hmacSHA256(kService, "aws4_request")
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
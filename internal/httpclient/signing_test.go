@@ -0,0 +1,87 @@
+package httpclient
+
+import "testing"
+
+func TestPreviewHMACSignatureMatchesKnownVector(t *testing.T) {
+	p := HMACSigningParams{
+		Method:    "POST",
+		URL:       "https://api.example.com/resource",
+		Body:      `{"hello":"world"}`,
+		Timestamp: "1700000000",
+		Secret:    "s3cr3t",
+	}
+	got := PreviewHMACSignature(p)
+
+	wantCanonical := "POST\nhttps://api.example.com/resource\n1700000000\n{\"hello\":\"world\"}"
+	if got.CanonicalString != wantCanonical {
+		t.Errorf("CanonicalString = %q, want %q", got.CanonicalString, wantCanonical)
+	}
+	// Computed independently via Python's hmac/hashlib against the same
+	// canonical string, so this checks our HMAC wiring rather than itself.
+	wantSig := "370c5e134b24e756ef8ec3183fda6a9faae98e1b24a6aa334358a20cc913174a"
+	if got.Signature != wantSig {
+		t.Errorf("Signature = %q, want %q", got.Signature, wantSig)
+	}
+}
+
+func TestPreviewOAuth1SignatureMatchesKnownVector(t *testing.T) {
+	p := OAuth1SigningParams{
+		Method: "POST",
+		URL:    "https://api.example.com/resource",
+		Params: map[string]string{
+			"oauth_consumer_key":     "xvz1evFS4wEEPTGEFPHBog",
+			"oauth_nonce":            "kYjzVBB8Y0ZFabxSWbWovY3uYSQ2pTgmZeNu2VS4cg",
+			"oauth_signature_method": "HMAC-SHA1",
+			"oauth_timestamp":        "1318622958",
+			"oauth_token":            "370773112-GmHxMAgYyLbNEtIKZeRNFsMKPR9EyMZeS9weJAEb",
+			"oauth_version":          "1.0",
+			"status":                 "Hello Ladies + Gentlemen, a signed OAuth request!",
+		},
+		ConsumerSecret: "kAcSOqF21Fu85e7zjz7ZN2U4ZRhfV3WpwPAoE3Z7kBw",
+		TokenSecret:    "LswwdoUaIvS8ltyTt5jkRh4J50vUPVVHtR2oulx1MuM",
+	}
+	got := PreviewOAuth1Signature(p)
+
+	wantBase := "POST&https%3A%2F%2Fapi.example.com%2Fresource&oauth_consumer_key%3Dxvz1evFS4wEEPTGEFPHBog%26oauth_nonce%3DkYjzVBB8Y0ZFabxSWbWovY3uYSQ2pTgmZeNu2VS4cg%26oauth_signature_method%3DHMAC-SHA1%26oauth_timestamp%3D1318622958%26oauth_token%3D370773112-GmHxMAgYyLbNEtIKZeRNFsMKPR9EyMZeS9weJAEb%26oauth_version%3D1.0%26status%3DHello%2520Ladies%2520%252B%2520Gentlemen%252C%2520a%2520signed%2520OAuth%2520request%2521"
+	if got.BaseString != wantBase {
+		t.Errorf("BaseString = %q, want %q", got.BaseString, wantBase)
+	}
+	// Computed independently via Python's hmac/base64 against the same base
+	// string and signing key.
+	wantSig := "3llEFCFwlPf8eeD/VrBkYe5XJxg="
+	if got.Signature != wantSig {
+		t.Errorf("Signature = %q, want %q", got.Signature, wantSig)
+	}
+}
+
+func TestPreviewSigV4SignatureMatchesKnownVector(t *testing.T) {
+	p := SigV4SigningParams{
+		Method: "GET",
+		URI:    "/",
+		Query:  "",
+		Headers: map[string]string{
+			"host":       "example.amazonaws.com",
+			"x-amz-date": "20150830T123600Z",
+		},
+		AmzDate:   "20150830T123600Z",
+		Region:    "us-east-1",
+		Service:   "service",
+		SecretKey: "wJalrXUtnFEMI/K7MDENG/bPxRfiCYEXAMPLEKEY",
+	}
+	got := PreviewSigV4Signature(p)
+
+	wantCanonical := "GET\n/\n\nhost:example.amazonaws.com\nx-amz-date:20150830T123600Z\n\nhost;x-amz-date\ne3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+	if got.CanonicalRequest != wantCanonical {
+		t.Errorf("CanonicalRequest = %q, want %q", got.CanonicalRequest, wantCanonical)
+	}
+	wantStringToSign := "AWS4-HMAC-SHA256\n20150830T123600Z\n20150830/us-east-1/service/aws4_request\nbb579772317eb040ac9ed261061d46c1f17a8133879d6129b6e1c25292927e63"
+	if got.StringToSign != wantStringToSign {
+		t.Errorf("StringToSign = %q, want %q", got.StringToSign, wantStringToSign)
+	}
+	// Computed independently via Python's hmac/hashlib through the same
+	// four-step signing-key derivation.
+	wantSig := "ea21d6f05e96a897f6000a1a293f0a5bf0f92a00343409e820dce329ca6365ea"
+	if got.Signature != wantSig {
+		t.Errorf("Signature = %q, want %q", got.Signature, wantSig)
+	}
+}
@@ -0,0 +1,45 @@
+package httpclient
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestDialTLSSkipVerifyAcceptsUntrustedCert(t *testing.T) {
+	srv := httptest.NewTLSServer(nil)
+	defer srv.Close()
+
+	client := NewClient()
+	resp, err := client.Send(context.Background(), RequestConfig{
+		Method: "GET",
+		URL:    srv.URL,
+		Tls:    &TlsConfig{SkipVerify: true},
+	})
+	if err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if resp.TlsInfo == nil || !resp.TlsInfo.VerificationSkipped {
+		t.Fatalf("TlsInfo.VerificationSkipped = %+v, want true", resp.TlsInfo)
+	}
+}
+
+func TestDialTLSWithoutSkipVerifyRejectsUntrustedCert(t *testing.T) {
+	srv := httptest.NewTLSServer(nil)
+	defer srv.Close()
+
+	// Reach the server via a hostname (rather than its bare 127.0.0.1 URL)
+	// so dialTLS actually attempts verification instead of skipping it for
+	// lacking an SNI hostname to verify against.
+	url := strings.Replace(srv.URL, "127.0.0.1", "localhost", 1)
+
+	client := NewClient()
+	_, err := client.Send(context.Background(), RequestConfig{
+		Method: "GET",
+		URL:    url,
+	})
+	if err == nil {
+		t.Fatal("expected an error for an unverified self-signed certificate")
+	}
+}
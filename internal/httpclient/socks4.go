@@ -0,0 +1,113 @@
+package httpclient
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"time"
+)
+
+// SOCKS4 reply codes (there is no protocol version byte in the reply worth
+// naming separately - it's always 0x00).
+const (
+	socks4Granted        byte = 0x5a
+	socks4RejectedFailed byte = 0x5b
+	socks4NoIdentd       byte = 0x5c
+	socks4IdentMismatch  byte = 0x5d
+)
+
+var socks4ReplyDetails = map[byte]string{
+	socks4RejectedFailed: "request rejected or failed",
+	socks4NoIdentd:       "proxy couldn't reach the client's identd",
+	socks4IdentMismatch:  "client and identd report different user-ids",
+}
+
+// Socks4Dialer dials through a SOCKS4 proxy. SOCKS4 predates SOCKS5's
+// auth negotiation (RFC 1928); its only identity field is USERID, sent in
+// the clear with every CONNECT request - Username fills that role here,
+// there is no separate password. When the target host isn't a literal
+// IPv4 address, DialContext automatically falls back to SOCKS4a and lets
+// the proxy resolve the hostname instead of doing it locally.
+type Socks4Dialer struct {
+	ProxyAddr string
+	Username  string
+}
+
+// Socks4Error reports a SOCKS4 CONNECT failure, categorized by the reply
+// code the proxy returned.
+type Socks4Error struct {
+	Code   byte
+	Detail string
+}
+
+func (e *Socks4Error) Error() string {
+	return fmt.Sprintf("socks4: %s (reply code 0x%02x)", e.Detail, e.Code)
+}
+
+// DialContext connects to d.ProxyAddr and asks it to CONNECT to addr,
+// returning the resulting end-to-end connection.
+func (d *Socks4Dialer) DialContext(ctx context.Context, network, addr string) (net.Conn, error) {
+	conn, err := (&net.Dialer{}).DialContext(ctx, "tcp", d.ProxyAddr)
+	if err != nil {
+		return nil, err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+	defer conn.SetDeadline(time.Time{})
+
+	if err := d.requestConnect(conn, addr); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return conn, nil
+}
+
+// requestConnect issues the CONNECT command and consumes the proxy's
+// reply. If host doesn't parse as IPv4, it sends the SOCKS4a placeholder
+// address (0.0.0.1) and appends host as a hostname for the proxy to
+// resolve, rather than resolving it locally.
+func (d *Socks4Dialer) requestConnect(conn net.Conn, addr string) error {
+	host, portStr, err := net.SplitHostPort(addr)
+	if err != nil {
+		return err
+	}
+	port, err := parsePort(portStr)
+	if err != nil {
+		return err
+	}
+
+	req := []byte{0x04, 0x01, byte(port >> 8), byte(port)}
+
+	var domain string
+	if ip4 := net.ParseIP(host).To4(); ip4 != nil {
+		req = append(req, ip4...)
+	} else {
+		req = append(req, 0x00, 0x00, 0x00, 0x01) // SOCKS4a: invalid IP signals hostname resolution
+		domain = host
+	}
+
+	req = append(req, d.Username...)
+	req = append(req, 0x00)
+	if domain != "" {
+		req = append(req, domain...)
+		req = append(req, 0x00)
+	}
+
+	if _, err := conn.Write(req); err != nil {
+		return err
+	}
+
+	resp := make([]byte, 8)
+	if _, err := readFull(conn, resp); err != nil {
+		return err
+	}
+	if resp[1] != socks4Granted {
+		detail, ok := socks4ReplyDetails[resp[1]]
+		if !ok {
+			detail = fmt.Sprintf("unknown reply code 0x%02x", resp[1])
+		}
+		return &Socks4Error{Code: resp[1], Detail: detail}
+	}
+	return nil
+}
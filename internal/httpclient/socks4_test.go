@@ -0,0 +1,117 @@
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+)
+
+// fakeSocks4Server runs a one-shot minimal SOCKS4 "server": it reads a
+// CONNECT request (optionally trailing a SOCKS4a hostname), records what it
+// saw, and replies with replyCode.
+func fakeSocks4Server(t *testing.T, replyCode byte, gotUserID *string, gotDomain *string) string {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	t.Cleanup(func() { ln.Close() })
+
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		header := make([]byte, 8)
+		if _, err := readFull(conn, header); err != nil {
+			return
+		}
+		isSocks4a := header[4] == 0 && header[5] == 0 && header[6] == 0 && header[7] != 0
+
+		userID, err := readUntilNull(conn)
+		if err != nil {
+			return
+		}
+		if gotUserID != nil {
+			*gotUserID = userID
+		}
+
+		if isSocks4a {
+			domain, err := readUntilNull(conn)
+			if err != nil {
+				return
+			}
+			if gotDomain != nil {
+				*gotDomain = domain
+			}
+		}
+
+		conn.Write([]byte{0x00, replyCode, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00})
+	}()
+
+	return ln.Addr().String()
+}
+
+func readUntilNull(conn net.Conn) (string, error) {
+	var out []byte
+	buf := make([]byte, 1)
+	for {
+		if _, err := readFull(conn, buf); err != nil {
+			return "", err
+		}
+		if buf[0] == 0x00 {
+			return string(out), nil
+		}
+		out = append(out, buf[0])
+	}
+}
+
+func TestSocks4DialerConnectsToIPv4Target(t *testing.T) {
+	var gotUserID string
+	proxyAddr := fakeSocks4Server(t, socks4Granted, &gotUserID, nil)
+
+	dialer := &Socks4Dialer{ProxyAddr: proxyAddr, Username: "alice"}
+	conn, err := dialer.DialContext(context.Background(), "tcp", "93.184.216.34:443")
+	if err != nil {
+		t.Fatalf("DialContext: %v", err)
+	}
+	conn.Close()
+
+	if gotUserID != "alice" {
+		t.Errorf("userid = %q, want %q", gotUserID, "alice")
+	}
+}
+
+func TestSocks4DialerUsesSocks4aForHostname(t *testing.T) {
+	var gotDomain string
+	proxyAddr := fakeSocks4Server(t, socks4Granted, nil, &gotDomain)
+
+	dialer := &Socks4Dialer{ProxyAddr: proxyAddr}
+	conn, err := dialer.DialContext(context.Background(), "tcp", "example.com:443")
+	if err != nil {
+		t.Fatalf("DialContext: %v", err)
+	}
+	conn.Close()
+
+	if gotDomain != "example.com" {
+		t.Errorf("domain = %q, want %q", gotDomain, "example.com")
+	}
+}
+
+func TestSocks4DialerReportsRejection(t *testing.T) {
+	proxyAddr := fakeSocks4Server(t, socks4RejectedFailed, nil, nil)
+
+	dialer := &Socks4Dialer{ProxyAddr: proxyAddr}
+	_, err := dialer.DialContext(context.Background(), "tcp", "93.184.216.34:443")
+
+	var socksErr *Socks4Error
+	if !errors.As(err, &socksErr) {
+		t.Fatalf("error = %v, want a *Socks4Error", err)
+	}
+	if socksErr.Code != socks4RejectedFailed {
+		t.Errorf("Code = 0x%02x, want 0x%02x", socksErr.Code, socks4RejectedFailed)
+	}
+}
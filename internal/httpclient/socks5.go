@@ -0,0 +1,237 @@
+package httpclient
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"time"
+)
+
+// SOCKS5 auth method identifiers, RFC 1928 §3.
+const (
+	socks5MethodNoAuth       byte = 0x00
+	socks5MethodGSSAPI       byte = 0x01
+	socks5MethodUserPass     byte = 0x02
+	socks5MethodNoAcceptable byte = 0xff
+)
+
+// socks5MethodNames lets error messages name the method a proxy rejected or
+// demanded, instead of just printing its wire byte.
+var socks5MethodNames = map[byte]string{
+	socks5MethodNoAuth:       "no-auth",
+	socks5MethodGSSAPI:       "GSSAPI",
+	socks5MethodUserPass:     "username/password",
+	socks5MethodNoAcceptable: "no acceptable method",
+}
+
+func socks5MethodName(method byte) string {
+	if name, ok := socks5MethodNames[method]; ok {
+		return name
+	}
+	return fmt.Sprintf("0x%02x", method)
+}
+
+// Socks5Dialer dials through a SOCKS5 proxy (RFC 1928). It supports no-auth
+// and username/password (RFC 1929); any other method the proxy selects -
+// GSSAPI included, since neither Go's stdlib nor this package implement it -
+// surfaces as a descriptive *Socks5Error instead of failing opaquely deeper
+// in the handshake.
+type Socks5Dialer struct {
+	ProxyAddr string
+	Username  string
+	Password  string
+
+	// PreferredMethod, when non-zero, is the only auth method offered to
+	// the proxy instead of the default "no-auth, plus username/password if
+	// credentials are set" list. Use this to force a specific negotiation
+	// path against a proxy with quirky method selection.
+	PreferredMethod byte
+}
+
+// Socks5ErrorCategory classifies why a SOCKS5 negotiation failed.
+type Socks5ErrorCategory string
+
+const (
+	Socks5ErrorUnsupportedMethod Socks5ErrorCategory = "unsupported_auth_method"
+	Socks5ErrorAuthFailed        Socks5ErrorCategory = "auth_failed"
+	Socks5ErrorConnectFailed     Socks5ErrorCategory = "connect_failed"
+)
+
+// Socks5Error reports a SOCKS5 negotiation failure with enough detail to
+// explain it to a user, rather than a raw "EOF" or "connection reset" from
+// deep inside the protocol exchange.
+type Socks5Error struct {
+	Category Socks5ErrorCategory
+	Detail   string
+}
+
+func (e *Socks5Error) Error() string {
+	return fmt.Sprintf("socks5: %s: %s", e.Category, e.Detail)
+}
+
+// DialContext connects to d.ProxyAddr, negotiates a SOCKS5 session, and asks
+// it to CONNECT to addr, returning the resulting end-to-end connection.
+func (d *Socks5Dialer) DialContext(ctx context.Context, network, addr string) (net.Conn, error) {
+	conn, err := (&net.Dialer{}).DialContext(ctx, "tcp", d.ProxyAddr)
+	if err != nil {
+		return nil, err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+	defer conn.SetDeadline(time.Time{})
+
+	if err := d.negotiateAuth(conn); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	if err := d.requestConnect(conn, addr); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return conn, nil
+}
+
+func (d *Socks5Dialer) offeredMethods() []byte {
+	if d.PreferredMethod != 0 {
+		return []byte{d.PreferredMethod}
+	}
+	if d.Username != "" {
+		return []byte{socks5MethodNoAuth, socks5MethodUserPass}
+	}
+	return []byte{socks5MethodNoAuth}
+}
+
+// negotiateAuth performs the SOCKS5 method-selection handshake (RFC 1928
+// §3) and, if the proxy selects username/password, the RFC 1929 sub-
+// negotiation. Any method other than no-auth or username/password - or the
+// proxy rejecting all offered methods with 0xff - is reported as an
+// unsupported_auth_method Socks5Error rather than attempted.
+func (d *Socks5Dialer) negotiateAuth(conn net.Conn) error {
+	methods := d.offeredMethods()
+	greeting := append([]byte{0x05, byte(len(methods))}, methods...)
+	if _, err := conn.Write(greeting); err != nil {
+		return err
+	}
+
+	resp := make([]byte, 2)
+	if _, err := readFull(conn, resp); err != nil {
+		return err
+	}
+	if resp[0] != 0x05 {
+		return &Socks5Error{Category: Socks5ErrorUnsupportedMethod, Detail: fmt.Sprintf("proxy replied with SOCKS version 0x%02x, want 0x05", resp[0])}
+	}
+
+	selected := resp[1]
+	switch selected {
+	case socks5MethodNoAuth:
+		return nil
+	case socks5MethodUserPass:
+		return d.authUserPass(conn)
+	case socks5MethodNoAcceptable:
+		return &Socks5Error{Category: Socks5ErrorUnsupportedMethod, Detail: "proxy rejected all offered auth methods"}
+	default:
+		return &Socks5Error{Category: Socks5ErrorUnsupportedMethod, Detail: fmt.Sprintf("proxy demands %s, which this client does not implement", socks5MethodName(selected))}
+	}
+}
+
+func (d *Socks5Dialer) authUserPass(conn net.Conn) error {
+	req := make([]byte, 0, 3+len(d.Username)+len(d.Password))
+	req = append(req, 0x01, byte(len(d.Username)))
+	req = append(req, d.Username...)
+	req = append(req, byte(len(d.Password)))
+	req = append(req, d.Password...)
+	if _, err := conn.Write(req); err != nil {
+		return err
+	}
+
+	resp := make([]byte, 2)
+	if _, err := readFull(conn, resp); err != nil {
+		return err
+	}
+	if resp[1] != 0x00 {
+		return &Socks5Error{Category: Socks5ErrorAuthFailed, Detail: "proxy rejected username/password credentials"}
+	}
+	return nil
+}
+
+// requestConnect issues the CONNECT command (RFC 1928 §4-5) for addr and
+// consumes the proxy's reply.
+func (d *Socks5Dialer) requestConnect(conn net.Conn, addr string) error {
+	host, portStr, err := net.SplitHostPort(addr)
+	if err != nil {
+		return err
+	}
+	port, err := parsePort(portStr)
+	if err != nil {
+		return err
+	}
+
+	req := []byte{0x05, 0x01, 0x00}
+	if ip := net.ParseIP(host); ip != nil {
+		if ip4 := ip.To4(); ip4 != nil {
+			req = append(req, 0x01)
+			req = append(req, ip4...)
+		} else {
+			req = append(req, 0x04)
+			req = append(req, ip.To16()...)
+		}
+	} else {
+		req = append(req, 0x03, byte(len(host)))
+		req = append(req, host...)
+	}
+	req = append(req, byte(port>>8), byte(port))
+
+	if _, err := conn.Write(req); err != nil {
+		return err
+	}
+
+	header := make([]byte, 4)
+	if _, err := readFull(conn, header); err != nil {
+		return err
+	}
+	if header[1] != 0x00 {
+		return &Socks5Error{Category: Socks5ErrorConnectFailed, Detail: fmt.Sprintf("proxy returned CONNECT reply code 0x%02x", header[1])}
+	}
+
+	var addrLen int
+	switch header[3] {
+	case 0x01:
+		addrLen = net.IPv4len
+	case 0x04:
+		addrLen = net.IPv6len
+	case 0x03:
+		lenByte := make([]byte, 1)
+		if _, err := readFull(conn, lenByte); err != nil {
+			return err
+		}
+		addrLen = int(lenByte[0])
+	default:
+		return &Socks5Error{Category: Socks5ErrorConnectFailed, Detail: fmt.Sprintf("proxy reply used unknown address type 0x%02x", header[3])}
+	}
+	if _, err := readFull(conn, make([]byte, addrLen+2)); err != nil { // bound address + port, unused
+		return err
+	}
+	return nil
+}
+
+func readFull(conn net.Conn, buf []byte) (int, error) {
+	n := 0
+	for n < len(buf) {
+		m, err := conn.Read(buf[n:])
+		n += m
+		if err != nil {
+			return n, err
+		}
+	}
+	return n, nil
+}
+
+func parsePort(s string) (int, error) {
+	port, err := strconv.ParseUint(s, 10, 16)
+	if err != nil {
+		return 0, fmt.Errorf("socks5: invalid port %q: %w", s, err)
+	}
+	return int(port), nil
+}
@@ -0,0 +1,93 @@
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"testing"
+)
+
+// fakeSocks5ServerDemanding runs a one-shot SOCKS5 "server" that always
+// selects selectedMethod regardless of what the client offers, so tests can
+// exercise the client's reaction to an unsupported or quirky selection.
+func fakeSocks5ServerDemanding(t *testing.T, selectedMethod byte) string {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	t.Cleanup(func() { ln.Close() })
+
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		greeting := make([]byte, 2)
+		if _, err := readFull(conn, greeting); err != nil {
+			return
+		}
+		nMethods := int(greeting[1])
+		if _, err := readFull(conn, make([]byte, nMethods)); err != nil {
+			return
+		}
+		conn.Write([]byte{0x05, selectedMethod})
+	}()
+
+	return ln.Addr().String()
+}
+
+func TestSocks5DialerReportsUnsupportedAuthMethod(t *testing.T) {
+	proxyAddr := fakeSocks5ServerDemanding(t, socks5MethodGSSAPI)
+
+	dialer := &Socks5Dialer{ProxyAddr: proxyAddr}
+	_, err := dialer.DialContext(context.Background(), "tcp", "example.com:443")
+	if err == nil {
+		t.Fatal("expected an error when the proxy demands GSSAPI")
+	}
+
+	var socksErr *Socks5Error
+	if !errors.As(err, &socksErr) {
+		t.Fatalf("error = %v, want a *Socks5Error", err)
+	}
+	if socksErr.Category != Socks5ErrorUnsupportedMethod {
+		t.Errorf("Category = %q, want %q", socksErr.Category, Socks5ErrorUnsupportedMethod)
+	}
+	if !strings.Contains(socksErr.Detail, "GSSAPI") {
+		t.Errorf("Detail = %q, want it to name GSSAPI", socksErr.Detail)
+	}
+}
+
+func TestSocks5DialerReportsNoAcceptableMethod(t *testing.T) {
+	proxyAddr := fakeSocks5ServerDemanding(t, socks5MethodNoAcceptable)
+
+	dialer := &Socks5Dialer{ProxyAddr: proxyAddr}
+	_, err := dialer.DialContext(context.Background(), "tcp", "example.com:443")
+
+	var socksErr *Socks5Error
+	if !errors.As(err, &socksErr) {
+		t.Fatalf("error = %v, want a *Socks5Error", err)
+	}
+	if socksErr.Category != Socks5ErrorUnsupportedMethod {
+		t.Errorf("Category = %q, want %q", socksErr.Category, Socks5ErrorUnsupportedMethod)
+	}
+}
+
+func TestSocks5DialerOffersUserPassWhenCredentialsSet(t *testing.T) {
+	dialer := &Socks5Dialer{Username: "alice", Password: "hunter2"}
+	methods := dialer.offeredMethods()
+	if len(methods) != 2 || methods[0] != socks5MethodNoAuth || methods[1] != socks5MethodUserPass {
+		t.Errorf("offeredMethods() = %v, want [no-auth, user-pass]", methods)
+	}
+}
+
+func TestSocks5DialerPreferredMethodOverridesDefaults(t *testing.T) {
+	dialer := &Socks5Dialer{Username: "alice", Password: "hunter2", PreferredMethod: socks5MethodGSSAPI}
+	methods := dialer.offeredMethods()
+	if len(methods) != 1 || methods[0] != socks5MethodGSSAPI {
+		t.Errorf("offeredMethods() = %v, want [GSSAPI]", methods)
+	}
+}
@@ -0,0 +1,56 @@
+package httpclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSendSuppressHeadersOmitsUserAgent(t *testing.T) {
+	var gotUA []string
+	var sawKey bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotUA, sawKey = r.Header["User-Agent"], true
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	client := NewClient()
+	_, err := client.Send(context.Background(), RequestConfig{
+		Method:          "GET",
+		URL:             srv.URL,
+		SuppressHeaders: []string{"User-Agent"},
+	})
+	if err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if !sawKey {
+		t.Fatal("handler never ran")
+	}
+	if len(gotUA) != 0 {
+		t.Errorf("User-Agent = %v, want omitted entirely", gotUA)
+	}
+}
+
+func TestSendHostHeaderOverridesWireHostOnH1(t *testing.T) {
+	var gotHost string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotHost = r.Host
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	client := NewClient()
+	_, err := client.Send(context.Background(), RequestConfig{
+		Method:  "GET",
+		URL:     srv.URL,
+		Headers: []HeaderField{{Name: "Host", Value: "spoofed.example"}},
+	})
+	if err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if gotHost != "spoofed.example" {
+		t.Errorf("Host = %q, want the overridden value (net/http otherwise drops a Header-set Host on HTTP/1.1)", gotHost)
+	}
+}
@@ -0,0 +1,80 @@
+package httpclient
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http/httptrace"
+	"time"
+)
+
+// timeNow is how timingTracker gets the current time by default. Tests
+// override this (see HappyEyeballsDelay for the same pattern) to feed a
+// deterministic fake clock instead of depending on wall-clock time.
+var timeNow = time.Now
+
+// Timing reports how long each phase of a request took.
+type Timing struct {
+	DNSLookup    time.Duration
+	TCPConnect   time.Duration
+	TLSHandshake time.Duration
+	// TTFB is time to first response byte, measured from when the request
+	// started (GetConn) rather than from when the connection was acquired.
+	TTFB  time.Duration
+	Total time.Duration
+}
+
+// timingTracker accumulates the httptrace.ClientTrace milestones needed to
+// compute a Timing. Its clock is injectable so tests can assert exact phase
+// durations instead of just "> 0".
+type timingTracker struct {
+	now func() time.Time
+
+	start, dnsStart, dnsDone     time.Time
+	connectStart, connectDone    time.Time
+	tlsStart, tlsDone, firstByte time.Time
+}
+
+// newTimingTracker returns a tracker using now as its clock, defaulting to
+// timeNow when now is nil.
+func newTimingTracker(now func() time.Time) *timingTracker {
+	if now == nil {
+		now = timeNow
+	}
+	return &timingTracker{now: now}
+}
+
+// withContext attaches an httptrace.ClientTrace to ctx that feeds t's
+// milestones as the request progresses.
+func (t *timingTracker) withContext(ctx context.Context) context.Context {
+	return httptrace.WithClientTrace(ctx, &httptrace.ClientTrace{
+		GetConn:              func(string) { t.start = t.now() },
+		DNSStart:             func(httptrace.DNSStartInfo) { t.dnsStart = t.now() },
+		DNSDone:              func(httptrace.DNSDoneInfo) { t.dnsDone = t.now() },
+		ConnectStart:         func(string, string) { t.connectStart = t.now() },
+		ConnectDone:          func(string, string, error) { t.connectDone = t.now() },
+		TLSHandshakeStart:    func() { t.tlsStart = t.now() },
+		TLSHandshakeDone:     func(tls.ConnectionState, error) { t.tlsDone = t.now() },
+		GotFirstResponseByte: func() { t.firstByte = t.now() },
+	})
+}
+
+// finish computes the elapsed Timing from the milestones recorded so far,
+// using t.now() as the end-of-request timestamp.
+func (t *timingTracker) finish() Timing {
+	return Timing{
+		DNSLookup:    timeDiff(t.dnsStart, t.dnsDone),
+		TCPConnect:   timeDiff(t.connectStart, t.connectDone),
+		TLSHandshake: timeDiff(t.tlsStart, t.tlsDone),
+		TTFB:         timeDiff(t.start, t.firstByte),
+		Total:        timeDiff(t.start, t.now()),
+	}
+}
+
+// timeDiff returns b-a, or zero if either milestone was never recorded
+// (e.g. TLSHandshake on a plain HTTP request).
+func timeDiff(a, b time.Time) time.Duration {
+	if a.IsZero() || b.IsZero() {
+		return 0
+	}
+	return b.Sub(a)
+}
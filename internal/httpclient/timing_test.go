@@ -0,0 +1,89 @@
+package httpclient
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http/httptrace"
+	"testing"
+	"time"
+)
+
+func TestTimingTrackerComputesExactPhaseDurations(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	var tick time.Duration
+	clock := func() time.Time {
+		now := base.Add(tick)
+		tick += 10 * time.Millisecond
+		return now
+	}
+
+	tracker := newTimingTracker(clock)
+	trace := httptrace.ContextClientTrace(tracker.withContext(context.Background()))
+
+	trace.GetConn("")                                  // t=0ms   -> start
+	trace.ConnectStart("tcp", "")                      // t=10ms  -> connectStart
+	trace.ConnectDone("tcp", "", nil)                  // t=20ms  -> connectDone (TCPConnect = 10ms)
+	trace.TLSHandshakeStart()                          // t=30ms  -> tlsStart
+	trace.TLSHandshakeDone(tls.ConnectionState{}, nil) // t=40ms -> tlsDone (TLSHandshake = 10ms)
+	trace.GotFirstResponseByte()                       // t=50ms  -> firstByte (TTFB = 50ms)
+
+	timing := tracker.finish() // t=60ms -> Total = 60ms
+
+	if timing.TCPConnect != 10*time.Millisecond {
+		t.Errorf("TCPConnect = %v, want 10ms", timing.TCPConnect)
+	}
+	if timing.TLSHandshake != 10*time.Millisecond {
+		t.Errorf("TLSHandshake = %v, want 10ms", timing.TLSHandshake)
+	}
+	if timing.TTFB != 50*time.Millisecond {
+		t.Errorf("TTFB = %v, want 50ms", timing.TTFB)
+	}
+	if timing.Total != 60*time.Millisecond {
+		t.Errorf("Total = %v, want 60ms", timing.Total)
+	}
+}
+
+func TestTimingTrackerComputesDNSLookupSeparatelyFromTCPConnect(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	var tick time.Duration
+	clock := func() time.Time {
+		now := base.Add(tick)
+		tick += 10 * time.Millisecond
+		return now
+	}
+
+	tracker := newTimingTracker(clock)
+	trace := httptrace.ContextClientTrace(tracker.withContext(context.Background()))
+
+	trace.GetConn("")                                           // t=0ms  -> start
+	trace.DNSStart(httptrace.DNSStartInfo{Host: "example.com"}) // t=10ms -> dnsStart
+	trace.DNSDone(httptrace.DNSDoneInfo{})                      // t=20ms -> dnsDone (DNSLookup = 10ms)
+	trace.ConnectStart("tcp", "")                               // t=30ms -> connectStart
+	trace.ConnectDone("tcp", "", nil)                           // t=40ms -> connectDone (TCPConnect = 10ms)
+
+	timing := tracker.finish()
+
+	if timing.DNSLookup != 10*time.Millisecond {
+		t.Errorf("DNSLookup = %v, want 10ms", timing.DNSLookup)
+	}
+	if timing.TCPConnect != 10*time.Millisecond {
+		t.Errorf("TCPConnect = %v, want 10ms (pure connect time, not including DNS)", timing.TCPConnect)
+	}
+}
+
+func TestTimingTrackerDNSLookupZeroWithoutDNSTrace(t *testing.T) {
+	tracker := newTimingTracker(nil)
+	trace := httptrace.ContextClientTrace(tracker.withContext(context.Background()))
+
+	// An IP-literal target never fires DNSStart/DNSDone - net's resolver
+	// short-circuits before reaching the trace hooks - so DNSLookup should
+	// stay zero instead of reporting a bogus duration.
+	trace.GetConn("")
+	trace.ConnectStart("tcp", "")
+	trace.ConnectDone("tcp", "", nil)
+
+	timing := tracker.finish()
+	if timing.DNSLookup != 0 {
+		t.Errorf("DNSLookup = %v, want 0 for a target with no DNS trace", timing.DNSLookup)
+	}
+}
@@ -0,0 +1,112 @@
+package httpclient
+
+import "time"
+
+// TlsConfig describes the TLS/HTTP2 fingerprint a request should present.
+// JA3, when set, takes precedence over Preset.
+type TlsConfig struct {
+	Preset string
+	JA3    string
+	Akamai string
+
+	// SupportedVersions, when non-empty, overrides the default
+	// GREASE/TLS1.3/TLS1.2 supported_versions extension order.
+	SupportedVersions []uint16
+
+	// EnableEarlyData offers TLS 1.3 0-RTT (early_data). Off by default;
+	// see tlsfp.ExtensionConfig.EnableEarlyData for why.
+	EnableEarlyData bool
+
+	// SNIOverride forces a specific SNI ServerName, overriding the default
+	// (the request host, or none for an IP-literal host).
+	SNIOverride string
+
+	// CurvePreferences, when non-empty, overrides crypto/tls's default
+	// supported_groups order with these wire values (see tlsfp curve
+	// constants), in preference order.
+	CurvePreferences []uint16
+
+	// HandshakeTimeout bounds the TLS handshake specifically, separate from
+	// the overall request context's deadline (which may be unset or long).
+	// Zero means defaultHandshakeTimeout.
+	HandshakeTimeout time.Duration
+
+	// CustomJA4, when set, overrides the JA4 HttpCall would otherwise
+	// derive from JA3, the same way JA3 itself overrides Preset.
+	CustomJA4 string
+
+	// ALPN, when non-empty, overrides the default {"h2", "http/1.1"}
+	// application-layer protocol list offered during the handshake - e.g.
+	// {"http/1.1"} forces HTTP/1.1 even against an HTTP/2-capable server,
+	// or a reordered list mimics a specific browser's ALPN order.
+	ALPN []string
+
+	// ClientCertPEM and ClientKeyPEM, when both set, present a client
+	// certificate during the handshake for mTLS-protected APIs. Both must
+	// be PEM-encoded; a malformed pair fails the request with a clear
+	// error (see ClassifyTlsError) instead of silently connecting without
+	// one.
+	ClientCertPEM string
+	ClientKeyPEM  string
+
+	// SkipVerify disables certificate verification entirely (wired through
+	// as tls.Config.InsecureSkipVerify), for testing against servers with
+	// self-signed or expired certs. TlsHandshakeInfo.VerificationSkipped
+	// reports back whenever this was used, so the UI can flag the
+	// connection as unauthenticated.
+	SkipVerify bool
+
+	// RootCAPEM, when set, replaces the system root pool with one built
+	// from these PEM-encoded certificates - for corporate MITM proxies or
+	// internal CAs the OS doesn't trust. May contain multiple concatenated
+	// PEM blocks; all of them are added to the pool. Empty keeps using the
+	// system pool.
+	RootCAPEM string
+
+	// RawMode strips every piece of implicit auto-behavior this package
+	// would otherwise add on top of a fingerprint - no GREASE, no default
+	// signature_algorithms, ALPN, or point-formats list - so the
+	// ClientHello carries exactly what SupportedVersions/ALPN/PointFormats
+	// (and JA3's ciphers/curves) say and nothing else, even if that's
+	// deliberately incomplete.
+	RawMode bool
+
+	// PointFormats, when non-empty, overrides the ec_point_formats list
+	// (extension 11) HttpCall reports advertising - e.g. {0, 1, 2} for an
+	// older TLS 1.2 fingerprint - taking precedence over whatever JA3's own
+	// point-formats field specified, and honored even when JA3 left that
+	// field blank (common among real clients that still send the
+	// extension). crypto/tls always sends uncompressed-only on the wire
+	// regardless, so this affects TlsHandshakeInfo.PointFormats reporting,
+	// not the literal handshake bytes.
+	PointFormats []uint8
+
+	// Renegotiation selects how the connection responds to a
+	// server-initiated TLS renegotiation request, for legacy servers that
+	// still demand one mid-connection (e.g. asking for a client
+	// certificate only after inspecting the initial request). Defaults to
+	// RenegotiateNever. TLS 1.3 has no renegotiation at all, so this only
+	// matters when the handshake negotiates TLS 1.2 or earlier.
+	Renegotiation RenegotiationSupport
+}
+
+// RenegotiationSupport mirrors crypto/tls.RenegotiationSupport with this
+// package's own type, the same way CurvePreferences uses plain uint16s
+// instead of tls.CurveID - so TlsConfig doesn't expose crypto/tls types
+// directly to callers.
+type RenegotiationSupport int
+
+const (
+	// RenegotiateNever refuses any renegotiation attempt - crypto/tls's
+	// own default.
+	RenegotiateNever RenegotiationSupport = iota
+	// RenegotiateOnceAsClient allows a single renegotiation, initiated by
+	// the server, per connection.
+	RenegotiateOnceAsClient
+	// RenegotiateFreely allows the server to renegotiate as many times as
+	// it likes over the life of the connection.
+	RenegotiateFreely
+)
+
+// defaultHandshakeTimeout is used when TlsConfig.HandshakeTimeout is zero.
+const defaultHandshakeTimeout = 10 * time.Second
@@ -0,0 +1,72 @@
+package httpclient
+
+import (
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+)
+
+// TlsErrorCategory classifies a TLS handshake failure so the UI can explain
+// it instead of showing a raw "TLS handshake failed: <err>" string.
+type TlsErrorCategory string
+
+const (
+	TlsErrorCertVerify        TlsErrorCategory = "cert_verify_failed"
+	TlsErrorSNIMismatch       TlsErrorCategory = "sni_mismatch"
+	TlsErrorVersionMismatch   TlsErrorCategory = "protocol_version_mismatch"
+	TlsErrorNoCipherOverlap   TlsErrorCategory = "no_cipher_overlap"
+	TlsErrorHandshakeTimeout  TlsErrorCategory = "handshake_timeout"
+	TlsErrorClientCertInvalid TlsErrorCategory = "client_cert_invalid"
+	TlsErrorRootCAInvalid     TlsErrorCategory = "root_ca_invalid"
+	TlsErrorUnknown           TlsErrorCategory = "unknown"
+)
+
+// TlsHandshakeError wraps a raw handshake error with its classified
+// category.
+type TlsHandshakeError struct {
+	Category TlsErrorCategory
+	Err      error
+}
+
+func (e *TlsHandshakeError) Error() string {
+	return fmt.Sprintf("TLS handshake failed: %s: %v", e.Category, e.Err)
+}
+
+func (e *TlsHandshakeError) Unwrap() error { return e.Err }
+
+// ClassifyTlsError inspects a handshake error and returns a structured
+// TlsHandshakeError describing which known failure mode it matches.
+func ClassifyTlsError(err error) *TlsHandshakeError {
+	if err == nil {
+		return nil
+	}
+
+	var unknownAuthErr x509.UnknownAuthorityError
+	var certInvalidErr x509.CertificateInvalidError
+	var hostnameErr x509.HostnameError
+	switch {
+	case errors.As(err, &unknownAuthErr), errors.As(err, &certInvalidErr):
+		return &TlsHandshakeError{Category: TlsErrorCertVerify, Err: err}
+	case errors.As(err, &hostnameErr):
+		return &TlsHandshakeError{Category: TlsErrorSNIMismatch, Err: err}
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return &TlsHandshakeError{Category: TlsErrorHandshakeTimeout, Err: err}
+	}
+
+	msg := err.Error()
+	switch {
+	case strings.Contains(msg, "protocol version not supported"):
+		return &TlsHandshakeError{Category: TlsErrorVersionMismatch, Err: err}
+	case strings.Contains(msg, "no cipher suite supported"):
+		return &TlsHandshakeError{Category: TlsErrorNoCipherOverlap, Err: err}
+	case strings.Contains(msg, "unrecognized name"):
+		return &TlsHandshakeError{Category: TlsErrorSNIMismatch, Err: err}
+	}
+
+	return &TlsHandshakeError{Category: TlsErrorUnknown, Err: err}
+}
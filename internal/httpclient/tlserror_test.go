@@ -0,0 +1,88 @@
+package httpclient
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// generateSelfSignedCert returns a fresh self-signed certificate for use by
+// a bare tls.Listener in tests that need to control the TLS config
+// themselves (httptest.NewTLSServer doesn't expose MinVersion).
+func generateSelfSignedCert(t *testing.T) tls.Certificate {
+	t.Helper()
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, &template, &template, &priv.PublicKey, priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: priv}
+}
+
+func TestClassifyTlsErrorCertVerifyFailure(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+
+	client := &http.Client{Transport: &http.Transport{}} // no InsecureSkipVerify, uses the real cert pool
+	_, err := client.Get(srv.URL)
+	if err == nil {
+		t.Fatal("expected a cert verification error")
+	}
+
+	classified := ClassifyTlsError(err)
+	if classified.Category != TlsErrorCertVerify {
+		t.Errorf("category = %q, want %q (err: %v)", classified.Category, TlsErrorCertVerify, err)
+	}
+}
+
+func TestClassifyTlsErrorVersionMismatch(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+
+	cert := generateSelfSignedCert(t)
+	serverCfg := &tls.Config{Certificates: []tls.Certificate{cert}, MinVersion: tls.VersionTLS13}
+	tlsLn := tls.NewListener(ln, serverCfg)
+
+	go func() {
+		conn, err := tlsLn.Accept()
+		if err != nil {
+			return
+		}
+		_ = conn.(*tls.Conn).Handshake()
+		conn.Close()
+	}()
+
+	clientCfg := &tls.Config{InsecureSkipVerify: true, MaxVersion: tls.VersionTLS12}
+	_, dialErr := tls.Dial("tcp", ln.Addr().String(), clientCfg)
+	if dialErr == nil {
+		t.Fatal("expected a version mismatch error")
+	}
+
+	classified := ClassifyTlsError(dialErr)
+	if classified.Category != TlsErrorVersionMismatch {
+		t.Errorf("category = %q, want %q (err: %v)", classified.Category, TlsErrorVersionMismatch, dialErr)
+	}
+}
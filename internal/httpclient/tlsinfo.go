@@ -0,0 +1,71 @@
+package httpclient
+
+import "time"
+
+// ServerCertInfo summarizes the leaf certificate the server presented,
+// without exposing the full x509.Certificate (and its raw DER bytes) to
+// callers that just want to show it in a UI.
+type ServerCertInfo struct {
+	SubjectCN string
+	IssuerCN  string
+	SANs      []string
+	NotBefore time.Time
+	NotAfter  time.Time
+}
+
+// TlsHandshakeInfo reports what actually happened during the TLS handshake,
+// as opposed to TlsConfig which describes what was requested.
+type TlsHandshakeInfo struct {
+	Version        string
+	NegotiatedALPN string
+	// ALPNOffered is the full ALPN protocol list the ClientHello advertised
+	// (see alpnProtocols), not just the one the server picked. Lets callers
+	// confirm their ALPN extension matches a target browser's order instead
+	// of only ever seeing the single negotiated value. crypto/tls doesn't
+	// expose what the server itself would have accepted beyond what it
+	// negotiated, so this is the offer only, not the server's full support.
+	ALPNOffered      []string
+	EarlyDataOffered bool
+	// EarlyDataAccepted reports whether the server accepted 0-RTT data.
+	// Only meaningful when EarlyDataOffered is true.
+	EarlyDataAccepted bool
+
+	// NegotiatedGroup is the curve/key-exchange group HttpCall believes the
+	// handshake settled on, as a name from tlsfp.GroupName. crypto/tls
+	// doesn't expose the server's actual selection, so this is inferred as
+	// the first entry of the client's own CurvePreferences - accurate
+	// whenever the server honors client preference order, which is the
+	// common case, but not a verified observation.
+	NegotiatedGroup string
+
+	// JA4Hash is the JA4 fingerprint string (tlsfp.CalculateJA4) for the
+	// ClientHello this connection presented, derived from TlsConfig.JA3's
+	// cipher/extension lists when one was configured (TlsConfig.CustomJA4
+	// overrides this). Empty when neither was set.
+	JA4Hash string
+
+	// JA4Raw is the JA4_r ("raw") variant of JA4Hash: the same prefix, but
+	// with the sorted cipher/extension lists spelled out in full instead of
+	// hashed (see tlsfp.CalculateJA4Raw). Empty under the same conditions as
+	// JA4Hash, plus whenever TlsConfig.CustomJA4 is set - an opaque custom
+	// hash has no raw list to show.
+	JA4Raw string
+
+	// ServerCert summarizes the leaf certificate the server presented, or
+	// nil if the peer sent none (e.g. a resumed session that skipped the
+	// Certificate message).
+	ServerCert *ServerCertInfo
+
+	// VerificationSkipped is true when TlsConfig.SkipVerify disabled
+	// certificate verification for this handshake, so the UI can warn that
+	// ServerCert and the connection itself weren't authenticated.
+	VerificationSkipped bool
+
+	// PointFormats is the ec_point_formats list HttpCall resolved for this
+	// handshake: TlsConfig.PointFormats if set, else whatever JA3's own
+	// point-formats field specified, else tlsfp's default of
+	// uncompressed-only. Like NegotiatedGroup, this reports what was
+	// configured rather than a wire-verified value - crypto/tls doesn't
+	// expose a way to set this extension's contents directly.
+	PointFormats []uint8
+}
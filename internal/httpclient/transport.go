@@ -0,0 +1,338 @@
+package httpclient
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"httpcall/internal/tlsfp"
+)
+
+// HeaderField is a single ordered HTTP/2-style header entry (used for the
+// pseudo-headers as well as regular ones, since HTTP/2 cares about order).
+// It also doubles as RequestConfig.Headers' element type, so a caller can
+// send two headers under the same name (e.g. repeated X-Forwarded-For) and
+// control their exact wire order - something a map can't represent.
+type HeaderField struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type headerOrderCtxKey struct{}
+
+// withHeaderOrder attaches the caller's RequestConfig.Headers order (lowercased
+// names, first occurrence only) to ctx so encodeHeaders can emit the h2
+// pseudo-header/regular-header list in that same order instead of whatever
+// order req.Header's map iteration happens to produce.
+func withHeaderOrder(ctx context.Context, order []string) context.Context {
+	return context.WithValue(ctx, headerOrderCtxKey{}, order)
+}
+
+func headerOrderFromContext(ctx context.Context) ([]string, bool) {
+	order, ok := ctx.Value(headerOrderCtxKey{}).([]string)
+	return order, ok
+}
+
+// headerOrder returns the lowercased header names from headers, in their
+// first-occurrence order, for withHeaderOrder.
+func headerOrder(headers []HeaderField) []string {
+	order := make([]string, 0, len(headers))
+	seen := make(map[string]bool, len(headers))
+	for _, h := range headers {
+		name := strings.ToLower(h.Name)
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		order = append(order, name)
+	}
+	return order
+}
+
+// CustomH2Transport is scaffolding for an HTTP/2 round tripper: the frame
+// encoding (encodeHeaders, PriorityFrameBytes), flush strategy (h2write.go),
+// flow control (h2flowcontrol.go), and connection pool (pool.go) all exist
+// and are unit-tested in isolation, but RoundTrip itself - the method that
+// would actually dial, write these frames, and read a response back off the
+// wire - is not implemented. Client.Send never constructs a
+// CustomH2Transport or routes through one; every real request, including
+// ones negotiating h2 over ALPN, goes out through stdlib's http.Transport
+// (see dialTLS). Nothing here currently affects a live request's wire
+// fingerprint. Until RoundTrip exists and Client.Send is wired to use it
+// (e.g. via http.Transport.TLSNextProto), treat this type as experimental.
+//
+// FlushStrategy controls how its outbound frames would reach the wire: the
+// default, FlushFullCoalesce, buffers the whole request (including body)
+// into one write for maximum fingerprint fidelity; FlushHeadersOnly trades
+// a little of that fidelity to stream large request bodies instead of
+// holding them fully in memory.
+//
+// pool (see connPool) is where a future RoundTrip would look for an
+// existing connection to a request's authority before dialing a new one,
+// so a SendRequestRepeat burst against the same host could reuse one
+// connection instead of paying a fresh TLS handshake per request.
+type CustomH2Transport struct {
+	TlsConfig     *TlsConfig
+	FlushStrategy FlushStrategy
+
+	// RawHeaderFields, when set, bypasses encodeHeaders entirely: these
+	// exact name/value pairs (pseudo-headers included) are sent verbatim in
+	// this order, with no canonicalization or pseudo/regular splitting.
+	// This is an escape hatch for reproducing a captured request exactly,
+	// for clients that need header framing encodeHeaders' conventions
+	// wouldn't otherwise produce.
+	RawHeaderFields []HeaderField
+
+	// StrippedHopByHop records the hop-by-hop header names the most recent
+	// resolveHeaderFields call removed from a request, since RFC 9113
+	// §8.2.2 forbids them in HTTP/2 (a server would answer with
+	// PROTOCOL_ERROR). Only populated on the encodeHeaders path -
+	// RawHeaderFields is a deliberate escape hatch and is sent verbatim.
+	StrippedHopByHop []string
+
+	// IdleConnTimeout bounds how long a pooled connection (see connPool) may
+	// sit idle before pool() evicts and closes it instead of handing it back
+	// for reuse. Zero means defaultIdleConnTimeout.
+	IdleConnTimeout time.Duration
+
+	connPool     *connPool
+	connPoolOnce sync.Once
+
+	// ServerSettings holds the most recently decoded SETTINGS frame from the
+	// peer (see decodeSettingsFrame) - nil until a future RoundTrip reads one
+	// off the wire and populates it. dataFrameSize uses it to size outbound
+	// DATA frames by what the server actually advertised.
+	ServerSettings *ServerH2Settings
+}
+
+// dataFrameSize returns how large a DATA frame chunk may be: the server's
+// advertised SETTINGS_MAX_FRAME_SIZE (see ServerSettings) when known, else
+// the conservative defaultMaxFrameSize RFC 9113 guarantees every peer
+// accepts.
+func (t *CustomH2Transport) dataFrameSize() int {
+	if t.ServerSettings != nil && t.ServerSettings.MaxFrameSize != nil {
+		return int(*t.ServerSettings.MaxFrameSize)
+	}
+	return defaultMaxFrameSize
+}
+
+// initialWindowSize returns the server's advertised connection/stream
+// SETTINGS_INITIAL_WINDOW_SIZE (see ServerSettings), or
+// defaultInitialWindowSize when unknown - the starting budget a future
+// RoundTrip would hand writeDataRespectingFlowControl for a new stream.
+func (t *CustomH2Transport) initialWindowSize() uint32 {
+	if t.ServerSettings != nil && t.ServerSettings.InitialWindowSize != nil {
+		return *t.ServerSettings.InitialWindowSize
+	}
+	return defaultInitialWindowSize
+}
+
+// pool lazily builds t's connection pool using IdleConnTimeout, so zero-value
+// CustomH2Transports (e.g. from NewCustomH2Transport) don't pay for one
+// until it's actually needed.
+func (t *CustomH2Transport) pool() *connPool {
+	t.connPoolOnce.Do(func() {
+		timeout := t.IdleConnTimeout
+		if timeout <= 0 {
+			timeout = defaultIdleConnTimeout
+		}
+		t.connPool = newConnPoolWithTimeout(timeout)
+	})
+	return t.connPool
+}
+
+// authorityKey returns the host:port CustomH2Transport.pool keys pooled
+// connections by for req - the same value encodeHeaders would put in
+// :authority, since that's what actually identifies the peer a connection
+// was dialed to.
+func authorityKey(req *http.Request) string {
+	authority := req.URL.Host
+	if h := req.Header.Get("Host"); h != "" {
+		authority = h
+	} else if req.Host != "" {
+		authority = req.Host
+	}
+	if _, _, err := net.SplitHostPort(authority); err != nil {
+		host := trimBrackets(authority)
+		if req.URL.Scheme == "https" {
+			authority = net.JoinHostPort(host, "443")
+		} else {
+			authority = net.JoinHostPort(host, "80")
+		}
+	}
+	return authority
+}
+
+// hopByHopHeaders are the headers RFC 9113 §8.2.2 forbids on an HTTP/2
+// request: they describe properties of a single TCP hop, which HTTP/2's
+// multiplexed, long-lived connections don't have a notion of. They're
+// legal (and sometimes meaningful) on HTTP/1.1, so this filtering only
+// applies to the h2 path.
+var hopByHopHeaders = map[string]bool{
+	"connection":        true,
+	"keep-alive":        true,
+	"proxy-connection":  true,
+	"transfer-encoding": true,
+	"upgrade":           true,
+}
+
+// NewCustomH2Transport builds a CustomH2Transport configured with cfg and the
+// default full-coalesce flush strategy. See CustomH2Transport's doc comment:
+// RoundTrip isn't implemented yet, so the result is scaffolding a future
+// caller would wire in, not something Client.Send can dial through today.
+func NewCustomH2Transport(cfg *TlsConfig) *CustomH2Transport {
+	return &CustomH2Transport{TlsConfig: cfg, FlushStrategy: FlushFullCoalesce}
+}
+
+// encodeHeaders builds the ordered HTTP/2 pseudo-header + header list for
+// req. The :authority pseudo-header honors an explicit Host header when the
+// caller set one, falling back to req.URL.Host, and strips default ports in
+// both cases so it stays consistent with what SNI will carry. Hop-by-hop
+// headers (hopByHopHeaders) are silently dropped - returned separately so
+// the caller can warn about them - since HTTP/2 forbids them outright.
+func encodeHeaders(req *http.Request) ([]HeaderField, []string) {
+	authority := req.URL.Host
+	if h := req.Header.Get("Host"); h != "" {
+		authority = h
+	} else if req.Host != "" {
+		authority = req.Host
+	}
+	authority = stripDefaultPort(authority, req.URL.Scheme)
+
+	path := req.URL.RequestURI()
+	if path == "" {
+		path = "/"
+	}
+
+	fields := []HeaderField{
+		{Name: ":method", Value: req.Method},
+		{Name: ":authority", Value: authority},
+		{Name: ":scheme", Value: req.URL.Scheme},
+		{Name: ":path", Value: path},
+	}
+
+	var stripped []string
+	emit := func(name string) {
+		if strings.EqualFold(name, "Host") {
+			return // folded into :authority above, not sent as a regular header
+		}
+		if hopByHopHeaders[strings.ToLower(name)] {
+			stripped = append(stripped, name)
+			return
+		}
+		for _, v := range req.Header[http.CanonicalHeaderKey(name)] {
+			fields = append(fields, HeaderField{Name: strings.ToLower(name), Value: v})
+		}
+	}
+
+	if order, ok := headerOrderFromContext(req.Context()); ok {
+		emitted := make(map[string]bool, len(order))
+		for _, name := range order {
+			emitted[name] = true
+			emit(name)
+		}
+		// Headers outside the caller's explicit order (e.g. auto-set
+		// Content-Type) fall back to req.Header's own map iteration order,
+		// same as when there's no order at all.
+		for name := range req.Header {
+			if emitted[strings.ToLower(name)] {
+				continue
+			}
+			emit(name)
+		}
+		return fields, stripped
+	}
+
+	for name := range req.Header {
+		emit(name)
+	}
+	return fields, stripped
+}
+
+// resolveHeaderFields returns t.RawHeaderFields verbatim when set (after
+// validating pseudo-header placement), or falls back to encodeHeaders(req),
+// recording any headers it stripped in t.StrippedHopByHop.
+func (t *CustomH2Transport) resolveHeaderFields(req *http.Request) ([]HeaderField, error) {
+	t.StrippedHopByHop = nil
+	if t.RawHeaderFields == nil {
+		fields, stripped := encodeHeaders(req)
+		t.StrippedHopByHop = stripped
+		return fields, nil
+	}
+	if err := validatePseudoHeadersFirst(t.RawHeaderFields); err != nil {
+		return nil, err
+	}
+	return t.RawHeaderFields, nil
+}
+
+// validatePseudoHeadersFirst enforces RFC 9113 §8.3: all pseudo-header
+// fields (":method", ":path", etc) must appear before any regular header.
+func validatePseudoHeadersFirst(fields []HeaderField) error {
+	seenRegular := false
+	for _, f := range fields {
+		isPseudo := strings.HasPrefix(f.Name, ":")
+		if isPseudo && seenRegular {
+			return fmt.Errorf("httpclient: pseudo-header %q appears after a regular header", f.Name)
+		}
+		if !isPseudo {
+			seenRegular = true
+		}
+	}
+	return nil
+}
+
+// PriorityFrameBytes returns the wire bytes of the PRIORITY frames t's
+// TlsConfig.Akamai fingerprint calls for, in order, ready to be written
+// before the HEADERS frame (see encodePriorityFrames). Returns nil with no
+// error when TlsConfig is nil, Akamai is unset, or the fingerprint's
+// priority field is the no-frames sentinel "0".
+func (t *CustomH2Transport) PriorityFrameBytes() ([]byte, error) {
+	if t.TlsConfig == nil || t.TlsConfig.Akamai == "" {
+		return nil, nil
+	}
+	fields, err := tlsfp.ParseAkamaiText(t.TlsConfig.Akamai)
+	if err != nil {
+		return nil, fmt.Errorf("httpclient: priority frames: %w", err)
+	}
+	if len(fields.PriorityFrames) == 0 {
+		return nil, nil
+	}
+	return encodePriorityFrames(fields.PriorityFrames), nil
+}
+
+// stripDefaultPort removes ":80" for http and ":443" for https from host, so
+// :authority matches what a real browser (and SNI) would send.
+func stripDefaultPort(host, scheme string) string {
+	h, port, err := net.SplitHostPort(host)
+	if err != nil {
+		return host
+	}
+	if (scheme == "https" && port == "443") || (scheme == "http" && port == "80") {
+		return bracketIPv6(h)
+	}
+	return host
+}
+
+// bracketIPv6 re-adds the "[" "]" net.SplitHostPort strips off an IPv6
+// literal (e.g. "::1" or "fe80::1%eth0"), leaving hostnames and IPv4
+// literals untouched, so a port-stripped :authority stays the well-formed
+// "[::1]" rather than the ambiguous bare "::1".
+func bracketIPv6(host string) string {
+	if strings.Contains(host, ":") {
+		return "[" + host + "]"
+	}
+	return host
+}
+
+// trimBrackets strips the enclosing "[" "]" from a bracketed IPv6 literal
+// like "[::1]", leaving plain hostnames and IPv4 literals untouched.
+func trimBrackets(host string) string {
+	if strings.HasPrefix(host, "[") && strings.HasSuffix(host, "]") {
+		return host[1 : len(host)-1]
+	}
+	return host
+}
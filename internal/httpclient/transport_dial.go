@@ -0,0 +1,71 @@
+package httpclient
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/http/httptrace"
+)
+
+// dial establishes a TLS connection to addr for a future RoundTrip, firing
+// the same httptrace.ClientTrace milestones net/http's own Transport fires
+// around a dial (ConnectStart/ConnectDone, TLSHandshakeStart/Done) - since
+// CustomH2Transport bypasses net/http's Transport entirely, nothing else
+// would ever call them, and timingTracker (see timing.go) depends on them
+// to populate Timing.TCPConnect/TLSHandshake for this path the same way it
+// already does for the http.Transport-based HTTP/1.1 path.
+func (t *CustomH2Transport) dial(ctx context.Context, addr string) (net.Conn, error) {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		host = addr
+	}
+
+	trace := httptrace.ContextClientTrace(ctx)
+	cfg := t.TlsConfig
+	serverName := chooseSNI(host, cfg)
+
+	if trace != nil && trace.ConnectStart != nil {
+		trace.ConnectStart("tcp", addr)
+	}
+	rawConn, err := (&net.Dialer{}).DialContext(ctx, "tcp", addr)
+	if trace != nil && trace.ConnectDone != nil {
+		trace.ConnectDone("tcp", addr, err)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	tlsCfg := &tls.Config{
+		ServerName:       serverName,
+		CurvePreferences: curvePreferences(cfg),
+		NextProtos:       alpnProtocols(cfg),
+		Renegotiation:    renegotiationSupport(cfg),
+	}
+	if serverName == "" {
+		tlsCfg.InsecureSkipVerify = true
+	}
+	if cfg != nil && cfg.SkipVerify {
+		tlsCfg.InsecureSkipVerify = true
+	}
+
+	handshakeCtx, cancel := context.WithTimeout(ctx, handshakeTimeout(cfg))
+	defer cancel()
+
+	if trace != nil && trace.TLSHandshakeStart != nil {
+		trace.TLSHandshakeStart()
+	}
+	conn := tls.Client(rawConn, tlsCfg)
+	err = conn.HandshakeContext(handshakeCtx)
+	if trace != nil && trace.TLSHandshakeDone != nil {
+		state := tls.ConnectionState{}
+		if err == nil {
+			state = conn.ConnectionState()
+		}
+		trace.TLSHandshakeDone(state, err)
+	}
+	if err != nil {
+		rawConn.Close()
+		return nil, ClassifyTlsError(err)
+	}
+	return conn, nil
+}
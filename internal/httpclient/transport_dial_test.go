@@ -0,0 +1,43 @@
+package httpclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestCustomH2TransportDialPopulatesTiming(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+	addr := strings.TrimPrefix(srv.URL, "https://")
+
+	transport := NewCustomH2Transport(&TlsConfig{SkipVerify: true})
+
+	tracker := newTimingTracker(nil)
+	ctx := tracker.withContext(context.Background())
+
+	conn, err := transport.dial(ctx, addr)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	defer conn.Close()
+
+	timing := tracker.finish()
+	if timing.TCPConnect <= 0 {
+		t.Error("TCPConnect = 0, want > 0")
+	}
+	if timing.TLSHandshake <= 0 {
+		t.Error("TLSHandshake = 0, want > 0")
+	}
+}
+
+func TestCustomH2TransportDialFailsForUnreachableAddr(t *testing.T) {
+	transport := NewCustomH2Transport(nil)
+	if _, err := transport.dial(context.Background(), "127.0.0.1:1"); err == nil {
+		t.Error("dial to an unreachable port, want an error")
+	}
+}
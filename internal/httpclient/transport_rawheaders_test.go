@@ -0,0 +1,57 @@
+package httpclient
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestResolveHeaderFieldsUsesRawFieldsVerbatim(t *testing.T) {
+	raw := []HeaderField{
+		{Name: ":method", Value: "POST"},
+		{Name: ":path", Value: "/exotic"},
+		{Name: "x-custom", Value: "value"},
+	}
+	transport := &CustomH2Transport{RawHeaderFields: raw}
+
+	req, _ := http.NewRequest("GET", "https://example.com/ignored", nil)
+	fields, err := transport.resolveHeaderFields(req)
+	if err != nil {
+		t.Fatalf("resolveHeaderFields: %v", err)
+	}
+	if !reflect.DeepEqual(fields, raw) {
+		t.Fatalf("fields = %+v, want exactly %+v", fields, raw)
+	}
+
+	block := encodeHeaderBlock(fields)
+	decoded, err := decodeHeaderBlock(block)
+	if err != nil {
+		t.Fatalf("decodeHeaderBlock: %v", err)
+	}
+	if !reflect.DeepEqual(decoded, raw) {
+		t.Fatalf("decoded header block = %+v, want %+v", decoded, raw)
+	}
+}
+
+func TestResolveHeaderFieldsRejectsPseudoAfterRegular(t *testing.T) {
+	transport := &CustomH2Transport{RawHeaderFields: []HeaderField{
+		{Name: "x-custom", Value: "value"},
+		{Name: ":path", Value: "/late"},
+	}}
+	req, _ := http.NewRequest("GET", "https://example.com/", nil)
+	if _, err := transport.resolveHeaderFields(req); err == nil {
+		t.Fatal("expected an error for a pseudo-header after a regular header")
+	}
+}
+
+func TestResolveHeaderFieldsFallsBackToEncodeHeaders(t *testing.T) {
+	transport := &CustomH2Transport{}
+	req, _ := http.NewRequest("GET", "https://example.com/path", nil)
+	fields, err := transport.resolveHeaderFields(req)
+	if err != nil {
+		t.Fatalf("resolveHeaderFields: %v", err)
+	}
+	if len(fields) == 0 || fields[0].Name != ":method" {
+		t.Fatalf("expected encodeHeaders output, got %+v", fields)
+	}
+}
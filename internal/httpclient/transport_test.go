@@ -0,0 +1,252 @@
+package httpclient
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func findHeader(fields []HeaderField, name string) (string, bool) {
+	for _, f := range fields {
+		if f.Name == name {
+			return f.Value, true
+		}
+	}
+	return "", false
+}
+
+func TestEncodeHeadersAuthorityDefaultsToURLHost(t *testing.T) {
+	req := &http.Request{
+		Method: "GET",
+		URL:    &url.URL{Scheme: "https", Host: "example.com:443", Path: "/"},
+		Header: http.Header{},
+	}
+	fields, _ := encodeHeaders(req)
+	got, _ := findHeader(fields, ":authority")
+	if got != "example.com" {
+		t.Errorf(":authority = %q, want %q", got, "example.com")
+	}
+}
+
+func TestEncodeHeadersAuthorityHonorsHostOverride(t *testing.T) {
+	req := &http.Request{
+		Method: "GET",
+		URL:    &url.URL{Scheme: "https", Host: "1.2.3.4:443", Path: "/"},
+		Header: http.Header{"Host": []string{"real.example.com"}},
+	}
+	fields, _ := encodeHeaders(req)
+	got, _ := findHeader(fields, ":authority")
+	if got != "real.example.com" {
+		t.Errorf(":authority = %q, want %q", got, "real.example.com")
+	}
+	if _, ok := findHeader(fields, "host"); ok {
+		t.Errorf("Host should not be emitted as a regular header")
+	}
+}
+
+func TestEncodeHeadersAuthorityBracketsIPv6Literal(t *testing.T) {
+	req := &http.Request{
+		Method: "GET",
+		URL:    &url.URL{Scheme: "https", Host: "[::1]:443", Path: "/"},
+		Header: http.Header{},
+	}
+	fields, _ := encodeHeaders(req)
+	got, _ := findHeader(fields, ":authority")
+	if got != "[::1]" {
+		t.Errorf(":authority = %q, want %q", got, "[::1]")
+	}
+}
+
+func TestEncodeHeadersAuthorityKeepsNonDefaultPortOnIPv6Literal(t *testing.T) {
+	req := &http.Request{
+		Method: "GET",
+		URL:    &url.URL{Scheme: "https", Host: "[::1]:8443", Path: "/"},
+		Header: http.Header{},
+	}
+	fields, _ := encodeHeaders(req)
+	got, _ := findHeader(fields, ":authority")
+	if got != "[::1]:8443" {
+		t.Errorf(":authority = %q, want %q", got, "[::1]:8443")
+	}
+}
+
+func TestStripDefaultPortBracketsIPv6Zone(t *testing.T) {
+	if got := stripDefaultPort("[fe80::1%eth0]:443", "https"); got != "[fe80::1%eth0]" {
+		t.Errorf("stripDefaultPort = %q, want %q", got, "[fe80::1%eth0]")
+	}
+}
+
+func TestPriorityFrameBytesFromAkamaiFingerprint(t *testing.T) {
+	tr := NewCustomH2Transport(&TlsConfig{Akamai: "1:65536,4:131072,5:16384|12517377|3:0:0:201,5:0:0:101|m,p,a,s"})
+	b, err := tr.PriorityFrameBytes()
+	if err != nil {
+		t.Fatalf("PriorityFrameBytes: %v", err)
+	}
+	if len(b) != 28 {
+		t.Fatalf("got %d bytes, want 28 (two PRIORITY frames)", len(b))
+	}
+}
+
+func TestPriorityFrameBytesZeroFieldIsEmpty(t *testing.T) {
+	tr := NewCustomH2Transport(&TlsConfig{Akamai: "1:65536,2:0,4:6291456,6:262144|15663105|0|m,a,s,p"})
+	b, err := tr.PriorityFrameBytes()
+	if err != nil {
+		t.Fatalf("PriorityFrameBytes: %v", err)
+	}
+	if len(b) != 0 {
+		t.Errorf("got %d bytes, want 0 for the no-priority-frames fingerprint", len(b))
+	}
+}
+
+func TestPriorityFrameBytesNilWithNoAkamaiConfigured(t *testing.T) {
+	tr := NewCustomH2Transport(&TlsConfig{})
+	b, err := tr.PriorityFrameBytes()
+	if err != nil || b != nil {
+		t.Errorf("PriorityFrameBytes() = %v, %v, want nil, nil", b, err)
+	}
+}
+
+func TestPriorityFrameBytesErrorsOnMalformedAkamai(t *testing.T) {
+	tr := NewCustomH2Transport(&TlsConfig{Akamai: "not-a-valid-fingerprint"})
+	if _, err := tr.PriorityFrameBytes(); err == nil {
+		t.Error("expected an error for a malformed Akamai fingerprint")
+	}
+}
+
+func TestAuthorityKeyAddsDefaultPort(t *testing.T) {
+	req := &http.Request{URL: &url.URL{Scheme: "https", Host: "example.com"}, Header: http.Header{}}
+	if got := authorityKey(req); got != "example.com:443" {
+		t.Errorf("authorityKey = %q, want example.com:443", got)
+	}
+}
+
+func TestAuthorityKeyPreservesExplicitPort(t *testing.T) {
+	req := &http.Request{URL: &url.URL{Scheme: "https", Host: "example.com:8443"}, Header: http.Header{}}
+	if got := authorityKey(req); got != "example.com:8443" {
+		t.Errorf("authorityKey = %q, want example.com:8443", got)
+	}
+}
+
+func TestAuthorityKeyHonorsHostOverride(t *testing.T) {
+	req := &http.Request{
+		URL:    &url.URL{Scheme: "https", Host: "1.2.3.4"},
+		Header: http.Header{"Host": []string{"real.example.com"}},
+	}
+	if got := authorityKey(req); got != "real.example.com:443" {
+		t.Errorf("authorityKey = %q, want real.example.com:443", got)
+	}
+}
+
+func TestAuthorityKeyBracketsIPv6LiteralWithDefaultPort(t *testing.T) {
+	req := &http.Request{URL: &url.URL{Scheme: "https", Host: "[::1]"}, Header: http.Header{}}
+	if got := authorityKey(req); got != "[::1]:443" {
+		t.Errorf("authorityKey = %q, want [::1]:443", got)
+	}
+}
+
+func TestAuthorityKeyPreservesExplicitPortOnIPv6Literal(t *testing.T) {
+	req := &http.Request{URL: &url.URL{Scheme: "https", Host: "[::1]:8443"}, Header: http.Header{}}
+	if got := authorityKey(req); got != "[::1]:8443" {
+		t.Errorf("authorityKey = %q, want [::1]:8443", got)
+	}
+}
+
+func TestCustomH2TransportPoolReusesSamePoolAcrossCalls(t *testing.T) {
+	tr := NewCustomH2Transport(&TlsConfig{})
+	if tr.pool() != tr.pool() {
+		t.Error("pool() returned different instances on repeated calls")
+	}
+}
+
+func TestCustomH2TransportPoolHonorsIdleConnTimeout(t *testing.T) {
+	tr := &CustomH2Transport{IdleConnTimeout: 5 * time.Second}
+	if got := tr.pool().idleTimeout; got != 5*time.Second {
+		t.Errorf("pool idleTimeout = %v, want 5s", got)
+	}
+}
+
+func TestCustomH2TransportPoolDefaultsIdleConnTimeout(t *testing.T) {
+	tr := NewCustomH2Transport(&TlsConfig{})
+	if got := tr.pool().idleTimeout; got != defaultIdleConnTimeout {
+		t.Errorf("pool idleTimeout = %v, want %v", got, defaultIdleConnTimeout)
+	}
+}
+
+func TestDataFrameSizeDefaultsWithNoServerSettings(t *testing.T) {
+	tr := NewCustomH2Transport(&TlsConfig{})
+	if got := tr.dataFrameSize(); got != defaultMaxFrameSize {
+		t.Errorf("dataFrameSize() = %d, want %d", got, defaultMaxFrameSize)
+	}
+}
+
+func TestDataFrameSizeHonorsServerSettings(t *testing.T) {
+	maxFrameSize := uint32(32768)
+	tr := NewCustomH2Transport(&TlsConfig{})
+	tr.ServerSettings = &ServerH2Settings{MaxFrameSize: &maxFrameSize}
+	if got := tr.dataFrameSize(); got != 32768 {
+		t.Errorf("dataFrameSize() = %d, want 32768", got)
+	}
+}
+
+func TestDataFrameSizeIgnoresServerSettingsWithoutMaxFrameSize(t *testing.T) {
+	tr := NewCustomH2Transport(&TlsConfig{})
+	tr.ServerSettings = &ServerH2Settings{}
+	if got := tr.dataFrameSize(); got != defaultMaxFrameSize {
+		t.Errorf("dataFrameSize() = %d, want %d", got, defaultMaxFrameSize)
+	}
+}
+
+func TestInitialWindowSizeDefaultsWithNoServerSettings(t *testing.T) {
+	tr := NewCustomH2Transport(&TlsConfig{})
+	if got := tr.initialWindowSize(); got != defaultInitialWindowSize {
+		t.Errorf("initialWindowSize() = %d, want %d", got, defaultInitialWindowSize)
+	}
+}
+
+func TestInitialWindowSizeHonorsServerSettings(t *testing.T) {
+	initial := uint32(131072)
+	tr := NewCustomH2Transport(&TlsConfig{})
+	tr.ServerSettings = &ServerH2Settings{InitialWindowSize: &initial}
+	if got := tr.initialWindowSize(); got != 131072 {
+		t.Errorf("initialWindowSize() = %d, want 131072", got)
+	}
+}
+
+func TestHeaderOrderLowercasesAndDropsRepeats(t *testing.T) {
+	order := headerOrder([]HeaderField{
+		{Name: "X-Forwarded-For", Value: "10.0.0.1"},
+		{Name: "Accept", Value: "*/*"},
+		{Name: "x-forwarded-for", Value: "10.0.0.2"},
+	})
+	want := []string{"x-forwarded-for", "accept"}
+	if len(order) != len(want) || order[0] != want[0] || order[1] != want[1] {
+		t.Errorf("headerOrder() = %v, want %v", order, want)
+	}
+}
+
+func TestEncodeHeadersFollowsExplicitOrderFromContext(t *testing.T) {
+	req := &http.Request{
+		Method: "GET",
+		URL:    &url.URL{Scheme: "https", Host: "example.com", Path: "/"},
+		Header: http.Header{
+			"Accept":          []string{"*/*"},
+			"X-Custom-Header": []string{"v"},
+		},
+	}
+	ctx := withHeaderOrder(req.Context(), []string{"x-custom-header", "accept"})
+	req = req.WithContext(ctx)
+
+	fields, _ := encodeHeaders(req)
+	var regularOrder []string
+	for _, f := range fields {
+		if !strings.HasPrefix(f.Name, ":") {
+			regularOrder = append(regularOrder, f.Name)
+		}
+	}
+	want := []string{"x-custom-header", "accept"}
+	if len(regularOrder) != len(want) || regularOrder[0] != want[0] || regularOrder[1] != want[1] {
+		t.Errorf("regular header order = %v, want %v", regularOrder, want)
+	}
+}
@@ -0,0 +1,66 @@
+package httpclient
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"testing"
+)
+
+// unixSocketURL builds the http+unix:// form of socketPath with urlPath
+// appended, matching the scheme rewriteUnixSocketURL expects.
+func unixSocketURL(socketPath, urlPath string) string {
+	return unixSocketScheme + "://" + url.PathEscape(socketPath) + urlPath
+}
+
+func TestSendOverUnixSocket(t *testing.T) {
+	socketPath := filepath.Join(t.TempDir(), "test.sock")
+	ln, err := net.Listen("unix", socketPath)
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	defer ln.Close()
+
+	srv := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1.40/ping" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte("pong"))
+	})}
+	go srv.Serve(ln)
+	defer srv.Close()
+
+	client := NewClient()
+	resp, err := client.Send(context.Background(), RequestConfig{
+		Method: "GET",
+		URL:    unixSocketURL(socketPath, "/v1.40/ping"),
+	})
+	if err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("StatusCode = %d, want 200", resp.StatusCode)
+	}
+	if resp.Body != "pong" {
+		t.Errorf("Body = %q, want %q", resp.Body, "pong")
+	}
+}
+
+func TestRewriteUnixSocketURLRejectsEmptyPath(t *testing.T) {
+	if _, _, err := rewriteUnixSocketURL(unixSocketScheme + ":///v1.40/ping"); err == nil {
+		t.Fatal("rewriteUnixSocketURL: want an error for a missing socket path")
+	}
+}
+
+func TestRewriteUnixSocketURLIgnoresOrdinaryURLs(t *testing.T) {
+	httpURL, path, err := rewriteUnixSocketURL("https://example.com/foo")
+	if err != nil {
+		t.Fatalf("rewriteUnixSocketURL: %v", err)
+	}
+	if httpURL != "" || path != "" {
+		t.Errorf("rewriteUnixSocketURL(ordinary URL) = (%q, %q), want both empty", httpURL, path)
+	}
+}
@@ -0,0 +1,74 @@
+// Package testserver provides an in-process TLS/HTTP test server so
+// fingerprint-sensitive tests don't depend on live services like
+// httpbin.org or tls.peet.ws, which made them flaky and network-dependent.
+package testserver
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+)
+
+// Recorded captures what the server observed about one request: the
+// negotiated TLS version/ALPN from the real handshake, and the header set
+// the request arrived with.
+type Recorded struct {
+	TLSVersion  uint16
+	ALPN        string
+	HeaderNames []string
+}
+
+// Server is an in-process HTTPS server that records what it observed about
+// each request instead of requiring a live internet connection.
+type Server struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	recorded []Recorded
+}
+
+// New starts a TLS test server. Every request is recorded and answered 200.
+func New() *Server {
+	s := &Server{}
+	s.Server = httptest.NewTLSServer(http.HandlerFunc(s.handle))
+	return s
+}
+
+// NewWithALPN starts a TLS test server that only accepts the given ALPN
+// protocols, so tests can verify what a client actually negotiated instead
+// of assuming the default h2-then-http/1.1 offer was honored.
+func NewWithALPN(protos []string) *Server {
+	s := &Server{}
+	s.Server = httptest.NewUnstartedServer(http.HandlerFunc(s.handle))
+	s.Server.EnableHTTP2 = true
+	s.Server.TLS = &tls.Config{NextProtos: protos}
+	s.Server.StartTLS()
+	return s
+}
+
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	rec := Recorded{HeaderNames: make([]string, 0, len(r.Header))}
+	if r.TLS != nil {
+		rec.TLSVersion = r.TLS.Version
+		rec.ALPN = r.TLS.NegotiatedProtocol
+	}
+	for name := range r.Header {
+		rec.HeaderNames = append(rec.HeaderNames, name)
+	}
+
+	s.mu.Lock()
+	s.recorded = append(s.recorded, rec)
+	s.mu.Unlock()
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// Requests returns everything recorded so far, in arrival order.
+func (s *Server) Requests() []Recorded {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make([]Recorded, len(s.recorded))
+	copy(out, s.recorded)
+	return out
+}
@@ -0,0 +1,113 @@
+package tlsfp
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// PriorityFrame is one HTTP/2 PRIORITY frame's worth of stream-priority
+// data, as parsed from an Akamai fingerprint's third field.
+type PriorityFrame struct {
+	StreamID  uint32
+	Exclusive bool
+	DependsOn uint32
+	Weight    uint8
+}
+
+// AkamaiFields is an Akamai HTTP/2 fingerprint string split into its four
+// pipe-separated fields: Settings, the initial connection WINDOW_UPDATE
+// increment, the PRIORITY frames sent before HEADERS, and the
+// pseudo-header order.
+type AkamaiFields struct {
+	// Settings holds each "id:value" SETTINGS parameter pair in the order
+	// the fingerprint specifies them.
+	Settings []string
+
+	// WindowUpdateIncrement is the connection-level WINDOW_UPDATE increment
+	// sent right after SETTINGS, "" if the fingerprint's second field was
+	// empty.
+	WindowUpdateIncrement string
+
+	// PriorityFrames are the PRIORITY frames to emit, in order, before the
+	// HEADERS frame. Empty when the fingerprint's third field is the
+	// literal "0" (the common case of a client that doesn't send any).
+	PriorityFrames []PriorityFrame
+
+	// PseudoHeaderOrder is the single-letter pseudo-header order from the
+	// fingerprint's fourth field (e.g. "m,a,s,p" for
+	// :method,:authority,:scheme,:path).
+	PseudoHeaderOrder []string
+}
+
+// ParseAkamaiText validates and splits a raw Akamai fingerprint string, e.g.
+// "1:65536,2:0,4:6291456,6:262144|15663105|3:0:0:201,5:0:0:101|m,a,s,p".
+func ParseAkamaiText(akamai string) (AkamaiFields, error) {
+	parts := strings.Split(akamai, "|")
+	if len(parts) != 4 {
+		return AkamaiFields{}, fmt.Errorf("tlsfp: Akamai string has %d pipe-separated fields, want 4", len(parts))
+	}
+
+	priorityFrames, err := ParsePriorityFrames(parts[2])
+	if err != nil {
+		return AkamaiFields{}, fmt.Errorf("tlsfp: Akamai priority frames field: %w", err)
+	}
+
+	return AkamaiFields{
+		Settings:              splitCommaList(parts[0]),
+		WindowUpdateIncrement: parts[1],
+		PriorityFrames:        priorityFrames,
+		PseudoHeaderOrder:     splitCommaList(parts[3]),
+	}, nil
+}
+
+// ParsePriorityFrames parses an Akamai fingerprint's priority-frames field,
+// a comma-separated list of "streamID:exclusive:dependsOn:weight" tuples.
+// The literal "0" means no PRIORITY frames are sent, returning nil rather
+// than an error.
+func ParsePriorityFrames(s string) ([]PriorityFrame, error) {
+	if s == "0" || s == "" {
+		return nil, nil
+	}
+
+	tuples := strings.Split(s, ",")
+	frames := make([]PriorityFrame, 0, len(tuples))
+	for _, tuple := range tuples {
+		fields := strings.Split(tuple, ":")
+		if len(fields) != 4 {
+			return nil, fmt.Errorf("tlsfp: priority tuple %q has %d fields, want 4", tuple, len(fields))
+		}
+		streamID, err := strconv.ParseUint(fields[0], 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("tlsfp: priority tuple %q: stream ID: %w", tuple, err)
+		}
+		exclusive, err := strconv.ParseUint(fields[1], 10, 1)
+		if err != nil {
+			return nil, fmt.Errorf("tlsfp: priority tuple %q: exclusive bit: %w", tuple, err)
+		}
+		dependsOn, err := strconv.ParseUint(fields[2], 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("tlsfp: priority tuple %q: dependent stream ID: %w", tuple, err)
+		}
+		weight, err := strconv.ParseUint(fields[3], 10, 8)
+		if err != nil {
+			return nil, fmt.Errorf("tlsfp: priority tuple %q: weight: %w", tuple, err)
+		}
+		frames = append(frames, PriorityFrame{
+			StreamID:  uint32(streamID),
+			Exclusive: exclusive == 1,
+			DependsOn: uint32(dependsOn),
+			Weight:    uint8(weight),
+		})
+	}
+	return frames, nil
+}
+
+// splitCommaList splits a ","-joined list, treating an empty string as zero
+// values rather than one empty value.
+func splitCommaList(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, ",")
+}
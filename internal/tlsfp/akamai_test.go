@@ -0,0 +1,63 @@
+package tlsfp
+
+import "testing"
+
+func TestParseAkamaiTextSplitsFourFields(t *testing.T) {
+	fields, err := ParseAkamaiText("1:65536,4:131072,5:16384|12517377|3:0:0:201,5:0:0:101|m,p,a,s")
+	if err != nil {
+		t.Fatalf("ParseAkamaiText: %v", err)
+	}
+	if len(fields.Settings) != 3 {
+		t.Errorf("Settings = %v, want 3 entries", fields.Settings)
+	}
+	if fields.WindowUpdateIncrement != "12517377" {
+		t.Errorf("WindowUpdateIncrement = %q, want 12517377", fields.WindowUpdateIncrement)
+	}
+	if len(fields.PseudoHeaderOrder) != 4 {
+		t.Errorf("PseudoHeaderOrder = %v, want 4 entries", fields.PseudoHeaderOrder)
+	}
+	want := []PriorityFrame{
+		{StreamID: 3, Exclusive: false, DependsOn: 0, Weight: 201},
+		{StreamID: 5, Exclusive: false, DependsOn: 0, Weight: 101},
+	}
+	if len(fields.PriorityFrames) != len(want) {
+		t.Fatalf("PriorityFrames = %v, want %v", fields.PriorityFrames, want)
+	}
+	for i := range want {
+		if fields.PriorityFrames[i] != want[i] {
+			t.Errorf("PriorityFrames[%d] = %+v, want %+v", i, fields.PriorityFrames[i], want[i])
+		}
+	}
+}
+
+func TestParseAkamaiTextZeroMeansNoPriorityFrames(t *testing.T) {
+	fields, err := ParseAkamaiText("1:65536,2:0,4:6291456,6:262144|15663105|0|m,a,s,p")
+	if err != nil {
+		t.Fatalf("ParseAkamaiText: %v", err)
+	}
+	if fields.PriorityFrames != nil {
+		t.Errorf("PriorityFrames = %v, want nil", fields.PriorityFrames)
+	}
+}
+
+func TestParseAkamaiTextWrongFieldCountErrors(t *testing.T) {
+	if _, err := ParseAkamaiText("1:65536|12517377|0"); err == nil {
+		t.Error("expected an error for a 3-field Akamai string")
+	}
+}
+
+func TestParsePriorityFramesRejectsMalformedTuple(t *testing.T) {
+	if _, err := ParsePriorityFrames("3:0:0"); err == nil {
+		t.Error("expected an error for a priority tuple with too few fields")
+	}
+}
+
+func TestParsePriorityFramesHonorsExclusiveBit(t *testing.T) {
+	frames, err := ParsePriorityFrames("3:1:0:201")
+	if err != nil {
+		t.Fatalf("ParsePriorityFrames: %v", err)
+	}
+	if len(frames) != 1 || !frames[0].Exclusive {
+		t.Errorf("frames = %+v, want a single exclusive frame", frames)
+	}
+}
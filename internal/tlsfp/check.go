@@ -0,0 +1,51 @@
+package tlsfp
+
+import "encoding/json"
+
+// PeetResponse is the subset of tls.peet.ws's JSON response that matters for
+// fingerprint verification.
+type PeetResponse struct {
+	TLS struct {
+		JA3     string `json:"ja3"`
+		JA3Hash string `json:"ja3_hash"`
+		JA4     string `json:"ja4"`
+	} `json:"tls"`
+	HTTP2 struct {
+		AkamaiFingerprint     string `json:"akamai_fingerprint"`
+		AkamaiFingerprintHash string `json:"akamai_fingerprint_hash"`
+	} `json:"http2"`
+}
+
+// ParsePeetResponse decodes a tls.peet.ws response body.
+func ParsePeetResponse(body []byte) (*PeetResponse, error) {
+	var r PeetResponse
+	if err := json.Unmarshal(body, &r); err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+// Result is the outcome of comparing an intended fingerprint against what a
+// checker service actually observed on the wire.
+type Result struct {
+	JA3Match    bool
+	JA4Match    bool
+	AkamaiMatch bool
+	Observed    PeetResponse
+}
+
+// Pass reports whether every fingerprint that was asserted matched.
+func (r Result) Pass() bool {
+	return r.JA3Match && r.JA4Match && r.AkamaiMatch
+}
+
+// Compare checks an observed peet.ws response against the intended JA3/JA4/
+// Akamai strings. An empty intended value is treated as "not asserted" and
+// always matches.
+func Compare(intendedJA3, intendedJA4, intendedAkamai string, observed *PeetResponse) Result {
+	res := Result{Observed: *observed}
+	res.JA3Match = intendedJA3 == "" || observed.TLS.JA3 == intendedJA3
+	res.JA4Match = intendedJA4 == "" || observed.TLS.JA4 == intendedJA4
+	res.AkamaiMatch = intendedAkamai == "" || observed.HTTP2.AkamaiFingerprint == intendedAkamai
+	return res
+}
@@ -0,0 +1,51 @@
+package tlsfp
+
+import "testing"
+
+const recordedPeetResponse = `{
+	"tls": {
+		"ja3": "771,4865-4866-4867-49195-49199-49196-49200-52393-52392-49171-49172-156-157-47-53,0-23-65281-10-11-35-16-5-13-18-51-45-43-27-17513,29-23-24,0",
+		"ja3_hash": "cd08e31494f9531f560d64c695473da9",
+		"ja4": "t13d1516h2_8daaf6152771_02713d6af862"
+	},
+	"http2": {
+		"akamai_fingerprint": "1:65536,2:0,4:6291456,6:262144|15663105|0|m,a,s,p",
+		"akamai_fingerprint_hash": "90224459f8bf8d22c5c9e07c0f31b59d"
+	}
+}`
+
+func TestCompareMatches(t *testing.T) {
+	observed, err := ParsePeetResponse([]byte(recordedPeetResponse))
+	if err != nil {
+		t.Fatalf("ParsePeetResponse: %v", err)
+	}
+
+	preset := Presets["chrome120"]
+	result := Compare(preset.JA3, "", preset.Akamai, observed)
+
+	if !result.JA3Match {
+		t.Errorf("JA3Match = false, want true")
+	}
+	if !result.AkamaiMatch {
+		t.Errorf("AkamaiMatch = false, want true")
+	}
+	if !result.Pass() {
+		t.Errorf("Pass() = false, want true")
+	}
+}
+
+func TestCompareMismatch(t *testing.T) {
+	observed, err := ParsePeetResponse([]byte(recordedPeetResponse))
+	if err != nil {
+		t.Fatalf("ParsePeetResponse: %v", err)
+	}
+
+	result := Compare("771,not-the-real-ja3", "", Presets["chrome120"].Akamai, observed)
+
+	if result.JA3Match {
+		t.Errorf("JA3Match = true, want false")
+	}
+	if result.Pass() {
+		t.Errorf("Pass() = true, want false")
+	}
+}
@@ -0,0 +1,31 @@
+package tlsfp
+
+import "fmt"
+
+// Named group (curve / key-exchange group) wire values from the
+// supported_groups extension, RFC 8446 §4.2.7 plus the X25519Kyber768Draft00
+// hybrid some browsers now offer for post-quantum key exchange.
+const (
+	GroupX25519              uint16 = 0x001d
+	GroupSecp256r1           uint16 = 0x0017
+	GroupSecp384r1           uint16 = 0x0018
+	GroupSecp521r1           uint16 = 0x0019
+	GroupX25519Kyber768Draft uint16 = 0x6399
+)
+
+var groupNames = map[uint16]string{
+	GroupX25519:              "X25519",
+	GroupSecp256r1:           "secp256r1",
+	GroupSecp384r1:           "secp384r1",
+	GroupSecp521r1:           "secp521r1",
+	GroupX25519Kyber768Draft: "X25519Kyber768Draft00",
+}
+
+// GroupName returns the human-readable name for a named group ID, or
+// "unknown (0x....)" if id isn't one HttpCall recognizes.
+func GroupName(id uint16) string {
+	if name, ok := groupNames[id]; ok {
+		return name
+	}
+	return fmt.Sprintf("unknown (0x%04x)", id)
+}
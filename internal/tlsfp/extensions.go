@@ -0,0 +1,144 @@
+package tlsfp
+
+// TLS version wire values, as they appear in the supported_versions
+// extension and ClientHello.legacy_version.
+const (
+	VersionGREASE uint16 = 0x0a0a
+	VersionTLS13  uint16 = 0x0304
+	VersionTLS12  uint16 = 0x0303
+	VersionTLS11  uint16 = 0x0302
+	VersionTLS10  uint16 = 0x0301
+)
+
+// ExtEarlyData is the TLS extension ID for early_data (0-RTT), RFC 8446 §4.2.10.
+const ExtEarlyData uint16 = 42
+
+// ExtSignatureAlgorithms is the TLS extension ID for signature_algorithms,
+// RFC 8446 §4.2.3.
+const ExtSignatureAlgorithms uint16 = 13
+
+// ExtALPN is the TLS extension ID for application_layer_protocol_negotiation,
+// RFC 7301.
+const ExtALPN uint16 = 16
+
+// ExtPointFormats is the TLS extension ID for ec_point_formats, RFC 8422 §5.1.
+const ExtPointFormats uint16 = 11
+
+// ExtensionConfig carries the per-extension settings mapExtensionIDs needs to
+// build a ClientHello that matches a specific fingerprint instead of a fixed
+// default.
+type ExtensionConfig struct {
+	// SupportedVersions is the exact, ordered supported_versions list
+	// (extension 43) to advertise, GREASE included wherever the fingerprint
+	// places it. Falls back to {GREASE, TLS1.3, TLS1.2} when empty.
+	SupportedVersions []uint16
+
+	// EnableEarlyData offers the early_data (0-RTT) extension. Off by
+	// default: 0-RTT data can be replayed by a man-in-the-middle, so callers
+	// must opt in explicitly rather than get it from a fingerprint preset.
+	EnableEarlyData bool
+
+	// SignatureAlgorithms is the exact, ordered signature_algorithms list
+	// (extension 13) to advertise. Falls back to defaultSigAlgs when empty,
+	// so fingerprints that don't specify one keep the existing behavior.
+	SignatureAlgorithms []uint16
+
+	// ALPN is the exact, ordered application_layer_protocol_negotiation
+	// list (extension 16) to advertise, e.g. {"http/1.1"} for an HTTP/1.1-
+	// only fingerprint or a reordered list to mimic a specific browser.
+	// Falls back to defaultALPN when empty.
+	ALPN []string
+
+	// PointFormats is the exact, ordered ec_point_formats list (extension
+	// 11) to advertise, e.g. {0, 1, 2} for an older TLS 1.2 fingerprint
+	// that offers the ansiX962_compressed formats alongside uncompressed.
+	// Falls back to defaultPointFormats when empty.
+	PointFormats []uint8
+
+	// RawMode disables every fallback above: SupportedVersions,
+	// SignatureAlgorithms, ALPN, and PointFormats are each emitted exactly
+	// as given, with no GREASE or default injected when left empty. For
+	// researchers who want the ClientHello to contain precisely what their
+	// JA3 specifies, including deliberately incomplete or "illegal"
+	// fingerprints.
+	RawMode bool
+}
+
+// ShouldIncludeExtension reports whether a ClientHello built from cfg should
+// carry extID at all. Most extensions are unconditional; early_data is
+// opt-in because of its replay implications.
+func ShouldIncludeExtension(extID uint16, cfg ExtensionConfig) bool {
+	switch extID {
+	case ExtEarlyData:
+		return cfg.EnableEarlyData
+	default:
+		return true
+	}
+}
+
+var defaultSupportedVersions = []uint16{VersionGREASE, VersionTLS13, VersionTLS12}
+
+// defaultSigAlgs is the signature_algorithms list used when a fingerprint
+// doesn't specify its own, matching a typical modern Chrome ClientHello.
+var defaultSigAlgs = []uint16{0x0403, 0x0804, 0x0401, 0x0503, 0x0805, 0x0501, 0x0806, 0x0601}
+
+// defaultALPN is the application_layer_protocol_negotiation list used when a
+// fingerprint doesn't specify its own.
+var defaultALPN = []string{"h2", "http/1.1"}
+
+// defaultPointFormats is the ec_point_formats list used when a fingerprint
+// doesn't specify its own: uncompressed only, what every modern TLS stack
+// sends.
+var defaultPointFormats = []uint8{0}
+
+// mapExtensionIDs returns the value list to encode for a given TLS extension
+// ID, honoring cfg overrides where the extension supports one.
+func mapExtensionIDs(extID uint16, cfg ExtensionConfig) []uint16 {
+	switch extID {
+	case 43: // supported_versions
+		if len(cfg.SupportedVersions) > 0 || cfg.RawMode {
+			return cfg.SupportedVersions
+		}
+		return defaultSupportedVersions
+	case ExtSignatureAlgorithms:
+		if len(cfg.SignatureAlgorithms) > 0 || cfg.RawMode {
+			return cfg.SignatureAlgorithms
+		}
+		return defaultSigAlgs
+	default:
+		return nil
+	}
+}
+
+// SupportedVersions returns the ordered supported_versions list that would be
+// advertised for cfg.
+func SupportedVersions(cfg ExtensionConfig) []uint16 {
+	return mapExtensionIDs(43, cfg)
+}
+
+// SignatureAlgorithms returns the ordered signature_algorithms list that
+// would be advertised for cfg.
+func SignatureAlgorithms(cfg ExtensionConfig) []uint16 {
+	return mapExtensionIDs(ExtSignatureAlgorithms, cfg)
+}
+
+// ALPNProtocols returns the ordered ALPN protocol list that would be
+// advertised for cfg. It's a separate function, rather than another
+// mapExtensionIDs case, because ALPN's values are strings, not uint16s.
+func ALPNProtocols(cfg ExtensionConfig) []string {
+	if len(cfg.ALPN) > 0 || cfg.RawMode {
+		return cfg.ALPN
+	}
+	return defaultALPN
+}
+
+// PointFormats returns the ordered ec_point_formats list that would be
+// advertised for cfg. Like ALPNProtocols, this is a separate function
+// rather than another mapExtensionIDs case because its values are uint8,
+// not uint16.
+func PointFormats(cfg ExtensionConfig) []uint8 {
+	if len(cfg.PointFormats) > 0 || cfg.RawMode {
+		return cfg.PointFormats
+	}
+	return defaultPointFormats
+}
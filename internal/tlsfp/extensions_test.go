@@ -0,0 +1,125 @@
+package tlsfp
+
+import "testing"
+
+func TestSupportedVersionsDefault(t *testing.T) {
+	got := SupportedVersions(ExtensionConfig{})
+	want := []uint16{VersionGREASE, VersionTLS13, VersionTLS12}
+	if !equalUint16(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestSupportedVersionsCustomOrder(t *testing.T) {
+	want := []uint16{VersionTLS13}
+	got := SupportedVersions(ExtensionConfig{SupportedVersions: want})
+	if !equalUint16(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestShouldIncludeExtensionEarlyData(t *testing.T) {
+	if ShouldIncludeExtension(ExtEarlyData, ExtensionConfig{}) {
+		t.Error("early_data should be excluded by default")
+	}
+	if !ShouldIncludeExtension(ExtEarlyData, ExtensionConfig{EnableEarlyData: true}) {
+		t.Error("early_data should be included when EnableEarlyData is set")
+	}
+}
+
+func TestSignatureAlgorithmsDefault(t *testing.T) {
+	got := SignatureAlgorithms(ExtensionConfig{})
+	if !equalUint16(got, defaultSigAlgs) {
+		t.Errorf("got %v, want %v", got, defaultSigAlgs)
+	}
+}
+
+func TestSignatureAlgorithmsCustomOrder(t *testing.T) {
+	want := []uint16{0x0403, 0x0804}
+	got := SignatureAlgorithms(ExtensionConfig{SignatureAlgorithms: want})
+	if !equalUint16(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestShouldIncludeExtensionDefaultsToIncluded(t *testing.T) {
+	if !ShouldIncludeExtension(10, ExtensionConfig{}) {
+		t.Error("unrelated extensions should default to included")
+	}
+}
+
+func TestRawModeSuppressesSupportedVersionsFallback(t *testing.T) {
+	got := SupportedVersions(ExtensionConfig{RawMode: true})
+	if len(got) != 0 {
+		t.Errorf("got %v, want no supported_versions injected in raw mode", got)
+	}
+}
+
+func TestRawModeSuppressesSignatureAlgorithmsFallback(t *testing.T) {
+	got := SignatureAlgorithms(ExtensionConfig{RawMode: true})
+	if len(got) != 0 {
+		t.Errorf("got %v, want no signature_algorithms injected in raw mode", got)
+	}
+}
+
+func TestRawModeSuppressesALPNFallback(t *testing.T) {
+	got := ALPNProtocols(ExtensionConfig{RawMode: true})
+	if len(got) != 0 {
+		t.Errorf("got %v, want no ALPN protocols injected in raw mode", got)
+	}
+}
+
+func TestPointFormatsDefault(t *testing.T) {
+	got := PointFormats(ExtensionConfig{})
+	want := []uint8{0}
+	if !equalUint8(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestPointFormatsCustomOrder(t *testing.T) {
+	want := []uint8{0, 1, 2}
+	got := PointFormats(ExtensionConfig{PointFormats: want})
+	if !equalUint8(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestRawModeSuppressesPointFormatsFallback(t *testing.T) {
+	got := PointFormats(ExtensionConfig{RawMode: true})
+	if len(got) != 0 {
+		t.Errorf("got %v, want no point formats injected in raw mode", got)
+	}
+}
+
+func equalUint8(a, b []uint8) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestRawModeStillHonorsExplicitValues(t *testing.T) {
+	want := []uint16{VersionTLS12}
+	got := SupportedVersions(ExtensionConfig{RawMode: true, SupportedVersions: want})
+	if !equalUint16(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func equalUint16(a, b []uint16) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
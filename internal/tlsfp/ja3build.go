@@ -0,0 +1,50 @@
+package tlsfp
+
+import (
+	"strconv"
+	"strings"
+)
+
+// BuildJA3String assembles the canonical five-field JA3 string -
+// SSLVersion,Ciphers,Extensions,Curves,PointFormats - from a ClientHello's
+// components, filtering GREASE values (RFC 8701) out of every list field.
+// GREASE values are randomized per connection specifically so they can't be
+// used to fingerprint a client, so JA3 (correctly) excludes them; leaving
+// them in would make every hash for the same browser unique instead of
+// stable.
+func BuildJA3String(version uint16, ciphers, extensions, curves, pointFormats []uint16) string {
+	return strings.Join([]string{
+		formatUint16(version),
+		joinFiltered(ciphers),
+		joinFiltered(extensions),
+		joinFiltered(curves),
+		joinFiltered(pointFormats),
+	}, ",")
+}
+
+// CalculateJA3 builds the JA3 string for the given ClientHello components
+// and returns it alongside its MD5 hash.
+func CalculateJA3(version uint16, ciphers, extensions, curves, pointFormats []uint16) (ja3, hash string) {
+	ja3 = BuildJA3String(version, ciphers, extensions, curves, pointFormats)
+	return ja3, CalculateJA3Hash(ja3)
+}
+
+func joinFiltered(values []uint16) string {
+	var kept []string
+	for _, v := range values {
+		if isGREASE(v) {
+			continue
+		}
+		kept = append(kept, formatUint16(v))
+	}
+	return strings.Join(kept, "-")
+}
+
+func formatUint16(v uint16) string {
+	return strconv.FormatUint(uint64(v), 10)
+}
+
+// isGREASE reports whether v follows the GREASE pattern 0x?a?a (RFC 8701).
+func isGREASE(v uint16) bool {
+	return v&0x0f0f == 0x0a0a
+}
@@ -0,0 +1,42 @@
+package tlsfp
+
+import "testing"
+
+func TestCalculateJA3FiltersGREASEAndRoundTrips(t *testing.T) {
+	ja3, hash := CalculateJA3(
+		VersionTLS13,
+		[]uint16{0x0a0a, 4865, 4866, 4867},
+		[]uint16{0x2a2a, 0, 23, 65281},
+		[]uint16{0x6a6a, 29, 23, 24},
+		[]uint16{0},
+	)
+
+	want := "772,4865-4866-4867,0-23-65281,29-23-24,0"
+	if ja3 != want {
+		t.Errorf("ja3 = %q, want %q", ja3, want)
+	}
+
+	fields, err := ParseJA3Text(ja3)
+	if err != nil {
+		t.Fatalf("ParseJA3Text round-trip: %v", err)
+	}
+	if len(fields.Ciphers) != 3 {
+		t.Errorf("got %d ciphers after GREASE filtering, want 3", len(fields.Ciphers))
+	}
+
+	if hash != CalculateJA3Hash(ja3) {
+		t.Errorf("hash %q does not match CalculateJA3Hash(ja3)", hash)
+	}
+}
+
+func TestIsGREASE(t *testing.T) {
+	greaseValues := []uint16{0x0a0a, 0x1a1a, 0x2a2a, 0xfafa}
+	for _, v := range greaseValues {
+		if !isGREASE(v) {
+			t.Errorf("isGREASE(0x%04x) = false, want true", v)
+		}
+	}
+	if isGREASE(4865) {
+		t.Error("isGREASE(4865) = true, want false")
+	}
+}
@@ -0,0 +1,13 @@
+package tlsfp
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+)
+
+// CalculateJA3Hash returns the MD5 hash of a JA3 string - the canonical
+// fingerprint representation most checker services report.
+func CalculateJA3Hash(ja3 string) string {
+	sum := md5.Sum([]byte(ja3))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,107 @@
+package tlsfp
+
+import "strings"
+
+// JA3Mutation is one systematically-generated variant of a base JA3 string,
+// for probing which deviations a fingerprint checker still accepts (or
+// flags) - e.g. to find out whether a checker keys off extension order, a
+// specific extension's presence, or a particular cipher.
+type JA3Mutation struct {
+	// Description identifies what this mutation changed relative to the
+	// base JA3, e.g. "remove extension 21" or "reverse extension order".
+	Description string `json:"description"`
+	// JA3 is the mutated JA3 string, ready to pass to a Client as
+	// TlsConfig.JA3 or hand to a checker directly.
+	JA3 string `json:"ja3"`
+}
+
+// mutationCipherPool is swapped in one at a time for changeCipher mutations
+// - ordinary, non-GREASE cipher suite IDs unlikely to already appear in a
+// base JA3, so each substitution reliably changes the field.
+var mutationCipherPool = []string{"49171", "49172", "156", "157"}
+
+// MutateJA3 systematically derives variants of baseJA3 for fingerprint
+// research: one per extension removed, one with the extension order
+// reversed, and one per cipher swapped for a different value. maxMutations
+// caps how many are returned (0 or negative means unlimited); mutations are
+// dropped from the end of that fixed generation order rather than sampled,
+// so repeated calls with a smaller cap are a prefix of calls with a larger
+// one.
+func MutateJA3(baseJA3 string, maxMutations int) ([]JA3Mutation, error) {
+	fields, err := ParseJA3Text(baseJA3)
+	if err != nil {
+		return nil, err
+	}
+
+	var mutations []JA3Mutation
+
+	for i := range fields.Extensions {
+		mutated := fields
+		mutated.Extensions = removeAt(fields.Extensions, i)
+		mutations = append(mutations, JA3Mutation{
+			Description: "remove extension " + fields.Extensions[i],
+			JA3:         rebuildJA3Text(mutated),
+		})
+	}
+
+	if len(fields.Extensions) > 1 {
+		mutated := fields
+		mutated.Extensions = reversed(fields.Extensions)
+		mutations = append(mutations, JA3Mutation{
+			Description: "reverse extension order",
+			JA3:         rebuildJA3Text(mutated),
+		})
+	}
+
+	for i, cipher := range fields.Ciphers {
+		replacement := mutationCipherPool[i%len(mutationCipherPool)]
+		if replacement == cipher {
+			replacement = mutationCipherPool[(i+1)%len(mutationCipherPool)]
+		}
+		mutated := fields
+		mutated.Ciphers = replaceAt(fields.Ciphers, i, replacement)
+		mutations = append(mutations, JA3Mutation{
+			Description: "change cipher " + cipher + " to " + replacement,
+			JA3:         rebuildJA3Text(mutated),
+		})
+	}
+
+	if maxMutations > 0 && len(mutations) > maxMutations {
+		mutations = mutations[:maxMutations]
+	}
+	return mutations, nil
+}
+
+// rebuildJA3Text re-joins fields' five (SignatureAlgorithms is JA3Fields-only,
+// not part of standard JA3, so it's deliberately left out here) comma-
+// separated groups into a JA3 string.
+func rebuildJA3Text(fields JA3Fields) string {
+	return strings.Join([]string{
+		fields.Version,
+		strings.Join(fields.Ciphers, "-"),
+		strings.Join(fields.Extensions, "-"),
+		strings.Join(fields.Curves, "-"),
+		strings.Join(fields.PointFormats, "-"),
+	}, ",")
+}
+
+func removeAt(values []string, i int) []string {
+	out := make([]string, 0, len(values)-1)
+	out = append(out, values[:i]...)
+	out = append(out, values[i+1:]...)
+	return out
+}
+
+func replaceAt(values []string, i int, replacement string) []string {
+	out := append([]string(nil), values...)
+	out[i] = replacement
+	return out
+}
+
+func reversed(values []string) []string {
+	out := make([]string, len(values))
+	for i, v := range values {
+		out[len(values)-1-i] = v
+	}
+	return out
+}
@@ -0,0 +1,63 @@
+package tlsfp
+
+import "testing"
+
+func TestMutateJA3ProducesExpectedVariants(t *testing.T) {
+	base := "771,4865-4866,0-23-65281,29-23-24,0"
+	mutations, err := MutateJA3(base, 0)
+	if err != nil {
+		t.Fatalf("MutateJA3: %v", err)
+	}
+
+	// 3 extensions removed one at a time + 1 reversal + 2 ciphers changed.
+	if len(mutations) != 6 {
+		t.Fatalf("len(mutations) = %d, want 6", len(mutations))
+	}
+
+	want := []JA3Mutation{
+		{Description: "remove extension 0", JA3: "771,4865-4866,23-65281,29-23-24,0"},
+		{Description: "remove extension 23", JA3: "771,4865-4866,0-65281,29-23-24,0"},
+		{Description: "remove extension 65281", JA3: "771,4865-4866,0-23,29-23-24,0"},
+		{Description: "reverse extension order", JA3: "771,4865-4866,65281-23-0,29-23-24,0"},
+		{Description: "change cipher 4865 to 49171", JA3: "771,49171-4866,0-23-65281,29-23-24,0"},
+		{Description: "change cipher 4866 to 49172", JA3: "771,4865-49172,0-23-65281,29-23-24,0"},
+	}
+	for i, m := range mutations {
+		if m != want[i] {
+			t.Errorf("mutations[%d] = %+v, want %+v", i, m, want[i])
+		}
+	}
+}
+
+func TestMutateJA3HonorsMaxMutations(t *testing.T) {
+	base := "771,4865-4866,0-23-65281,29-23-24,0"
+	mutations, err := MutateJA3(base, 2)
+	if err != nil {
+		t.Fatalf("MutateJA3: %v", err)
+	}
+	if len(mutations) != 2 {
+		t.Fatalf("len(mutations) = %d, want 2", len(mutations))
+	}
+	if mutations[0].Description != "remove extension 0" || mutations[1].Description != "remove extension 23" {
+		t.Errorf("mutations = %+v, want the first two removals", mutations)
+	}
+}
+
+func TestMutateJA3RejectsMalformedBase(t *testing.T) {
+	if _, err := MutateJA3("not-a-ja3", 0); err == nil {
+		t.Fatal("MutateJA3: want error for malformed base JA3")
+	}
+}
+
+func TestMutateJA3SkipsReversalForSingleExtension(t *testing.T) {
+	base := "771,4865,0,29,0"
+	mutations, err := MutateJA3(base, 0)
+	if err != nil {
+		t.Fatalf("MutateJA3: %v", err)
+	}
+	for _, m := range mutations {
+		if m.Description == "reverse extension order" {
+			t.Errorf("reversal mutation shouldn't be generated for a single extension, got %+v", mutations)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package tlsfp
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// JA3Fields is a JA3 string split into its five comma-separated fields:
+// SSLVersion,Ciphers,Extensions,Curves,PointFormats. Ciphers/Extensions/
+// Curves/PointFormats are further split on "-" into their individual
+// values.
+type JA3Fields struct {
+	Version      string
+	Ciphers      []string
+	Extensions   []string
+	Curves       []string
+	PointFormats []string
+
+	// SignatureAlgorithms is an optional sixth comma-group, not part of
+	// standard JA3 but accepted here so callers can pin the
+	// signature_algorithms extension (13) a fingerprint builds, instead of
+	// always getting defaultSigAlgs. Nil when the input JA3 string didn't
+	// include a sixth field.
+	SignatureAlgorithms []uint16
+}
+
+// ParseJA3Text validates and splits a raw JA3 string, e.g.
+// "771,4865-4866-4867,0-23-65281,29-23-24,0". An empty or malformed string
+// (wrong field count) returns an error instead of a zero-value JA3Fields so
+// callers can tell "parsed to nothing" apart from "wasn't a JA3 string". A
+// sixth comma-group is accepted for SignatureAlgorithms; see its doc comment.
+func ParseJA3Text(ja3 string) (JA3Fields, error) {
+	parts := strings.Split(ja3, ",")
+	if len(parts) != 5 && len(parts) != 6 {
+		return JA3Fields{}, fmt.Errorf("tlsfp: JA3 string has %d comma-separated fields, want 5 or 6", len(parts))
+	}
+	fields := JA3Fields{
+		Version:      parts[0],
+		Ciphers:      splitDashList(parts[1]),
+		Extensions:   splitDashList(parts[2]),
+		Curves:       splitDashList(parts[3]),
+		PointFormats: splitDashList(parts[4]),
+	}
+	if len(parts) == 6 && parts[5] != "" {
+		sigAlgs, err := parseUint16DashList(parts[5])
+		if err != nil {
+			return JA3Fields{}, fmt.Errorf("tlsfp: JA3 signature_algorithms field: %w", err)
+		}
+		fields.SignatureAlgorithms = sigAlgs
+	}
+	return fields, nil
+}
+
+// parseUint16DashList splits a "-"-joined list of decimal values into
+// uint16s, erroring on the first value that doesn't parse.
+func parseUint16DashList(s string) ([]uint16, error) {
+	raw := splitDashList(s)
+	out := make([]uint16, len(raw))
+	for i, v := range raw {
+		n, err := strconv.ParseUint(v, 10, 16)
+		if err != nil {
+			return nil, err
+		}
+		out[i] = uint16(n)
+	}
+	return out, nil
+}
+
+// splitDashList splits a "-"-joined list, treating an empty string as zero
+// values rather than one empty value.
+func splitDashList(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, "-")
+}
@@ -0,0 +1,51 @@
+package tlsfp
+
+import "testing"
+
+func TestParseJA3Text(t *testing.T) {
+	fields, err := ParseJA3Text("771,4865-4866-4867,0-23-65281,29-23-24,0")
+	if err != nil {
+		t.Fatalf("ParseJA3Text: %v", err)
+	}
+	if fields.Version != "771" {
+		t.Errorf("Version = %q, want 771", fields.Version)
+	}
+	if len(fields.Ciphers) != 3 {
+		t.Errorf("got %d ciphers, want 3", len(fields.Ciphers))
+	}
+	if len(fields.PointFormats) != 1 || fields.PointFormats[0] != "0" {
+		t.Errorf("PointFormats = %v, want [0]", fields.PointFormats)
+	}
+}
+
+func TestParseJA3TextRejectsWrongFieldCount(t *testing.T) {
+	if _, err := ParseJA3Text("771,4865-4866"); err == nil {
+		t.Fatal("expected an error for a JA3 string missing fields")
+	}
+}
+
+func TestParseJA3TextOptionalSignatureAlgorithms(t *testing.T) {
+	fields, err := ParseJA3Text("771,4865-4866-4867,0-23-65281,29-23-24,0,1027-515")
+	if err != nil {
+		t.Fatalf("ParseJA3Text: %v", err)
+	}
+	want := []uint16{1027, 515}
+	if len(fields.SignatureAlgorithms) != len(want) {
+		t.Fatalf("SignatureAlgorithms = %v, want %v", fields.SignatureAlgorithms, want)
+	}
+	for i, v := range want {
+		if fields.SignatureAlgorithms[i] != v {
+			t.Errorf("SignatureAlgorithms[%d] = %d, want %d", i, fields.SignatureAlgorithms[i], v)
+		}
+	}
+}
+
+func TestParseJA3TextWithoutSignatureAlgorithmsLeavesItNil(t *testing.T) {
+	fields, err := ParseJA3Text("771,4865-4866-4867,0-23-65281,29-23-24,0")
+	if err != nil {
+		t.Fatalf("ParseJA3Text: %v", err)
+	}
+	if fields.SignatureAlgorithms != nil {
+		t.Errorf("SignatureAlgorithms = %v, want nil", fields.SignatureAlgorithms)
+	}
+}
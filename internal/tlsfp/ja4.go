@@ -0,0 +1,130 @@
+package tlsfp
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// JA4Params is the subset of a ClientHello JA4 summarizes.
+type JA4Params struct {
+	// Protocol is "t" for TCP (the only transport HttpCall dials today) or
+	// "q" for QUIC.
+	Protocol     string
+	TLSVersion   uint16
+	SNIPresent   bool
+	CipherSuites []uint16
+	Extensions   []uint16
+	// ALPN is the negotiated (or first offered) application protocol, ""
+	// if none.
+	ALPN string
+}
+
+// CalculateJA4 builds the JA4 fingerprint string for p, following the FoxIO
+// JA4 spec: a human-readable prefix (protocol, TLS version, SNI presence,
+// cipher/extension counts, first+last ALPN characters), then truncated
+// SHA-256 hashes of the sorted cipher-suite and extension lists. GREASE
+// values (RFC 8701) are excluded from every count and hash, same as JA3 -
+// see BuildJA3String.
+func CalculateJA4(p JA4Params) string {
+	ciphers := filterGREASE(p.CipherSuites)
+	extensions := filterGREASE(p.Extensions)
+
+	sni := "i"
+	if p.SNIPresent {
+		sni = "d"
+	}
+
+	prefix := fmt.Sprintf("%s%s%s%02d%02d%s",
+		p.Protocol, ja4VersionCode(p.TLSVersion), sni,
+		min(len(ciphers), 99), min(len(extensions), 99), ja4ALPNCode(p.ALPN))
+
+	return prefix + "_" + ja4TruncatedHash(sortedCSV(ciphers)) + "_" + ja4TruncatedHash(sortedCSV(extensions))
+}
+
+// CalculateJA4Raw builds the JA4_r ("raw") variant of p's fingerprint:
+// identical prefix to CalculateJA4, but with the sorted cipher and
+// extension lists spelled out directly instead of hashed, so a JA4
+// mismatch can be debugged down to the exact cipher or extension that
+// differs - the hashed form alone can't tell two different ClientHellos
+// apart beyond "they're different".
+func CalculateJA4Raw(p JA4Params) string {
+	ciphers := filterGREASE(p.CipherSuites)
+	extensions := filterGREASE(p.Extensions)
+
+	sni := "i"
+	if p.SNIPresent {
+		sni = "d"
+	}
+
+	prefix := fmt.Sprintf("%s%s%s%02d%02d%s",
+		p.Protocol, ja4VersionCode(p.TLSVersion), sni,
+		min(len(ciphers), 99), min(len(extensions), 99), ja4ALPNCode(p.ALPN))
+
+	return prefix + "_" + sortedCSV(ciphers) + "_" + sortedCSV(extensions)
+}
+
+func ja4VersionCode(v uint16) string {
+	switch v {
+	case VersionTLS13:
+		return "13"
+	case VersionTLS12:
+		return "12"
+	case VersionTLS11:
+		return "11"
+	case VersionTLS10:
+		return "10"
+	default:
+		return "00"
+	}
+}
+
+// ja4ALPNCode is JA4's "a" segment: the first and last character of the
+// negotiated ALPN value, or "00" when there isn't one.
+func ja4ALPNCode(alpn string) string {
+	if alpn == "" {
+		return "00"
+	}
+	runes := []rune(alpn)
+	if len(runes) == 1 {
+		return string(runes[0]) + string(runes[0])
+	}
+	return string(runes[0]) + string(runes[len(runes)-1])
+}
+
+func filterGREASE(values []uint16) []uint16 {
+	var kept []uint16
+	for _, v := range values {
+		if isGREASE(v) {
+			continue
+		}
+		kept = append(kept, v)
+	}
+	return kept
+}
+
+// sortedCSV sorts values ascending and joins them as decimal strings - JA4
+// hashes the sorted list so cipher/extension reordering (which doesn't
+// change what a server sees functionally) doesn't change the fingerprint.
+func sortedCSV(values []uint16) string {
+	sorted := append([]uint16(nil), values...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	strs := make([]string, len(sorted))
+	for i, v := range sorted {
+		strs[i] = strconv.FormatUint(uint64(v), 10)
+	}
+	return strings.Join(strs, ",")
+}
+
+// ja4TruncatedHash returns the first 12 hex characters of SHA-256(s), or 12
+// zeros for an empty input (JA4's convention for "nothing to hash").
+func ja4TruncatedHash(s string) string {
+	if s == "" {
+		return strings.Repeat("0", 12)
+	}
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])[:12]
+}
@@ -0,0 +1,77 @@
+package tlsfp
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCalculateJA4Format(t *testing.T) {
+	ja4 := CalculateJA4(JA4Params{
+		Protocol:     "t",
+		TLSVersion:   VersionTLS13,
+		SNIPresent:   true,
+		CipherSuites: []uint16{0x0a0a, 4865, 4866, 4867},
+		Extensions:   []uint16{0x2a2a, 0, 23, 65281},
+		ALPN:         "h2",
+	})
+
+	parts := strings.Split(ja4, "_")
+	if len(parts) != 3 {
+		t.Fatalf("got %d underscore-separated parts, want 3: %q", len(parts), ja4)
+	}
+	if parts[0] != "t13d0303h2" {
+		t.Errorf("prefix = %q, want t13d0303h2", parts[0])
+	}
+	if len(parts[1]) != 12 || len(parts[2]) != 12 {
+		t.Errorf("hash segments = %q, %q, want 12 hex chars each", parts[1], parts[2])
+	}
+}
+
+func TestCalculateJA4IsOrderIndependent(t *testing.T) {
+	a := CalculateJA4(JA4Params{Protocol: "t", TLSVersion: VersionTLS13, CipherSuites: []uint16{1, 2, 3}, Extensions: []uint16{4, 5}})
+	b := CalculateJA4(JA4Params{Protocol: "t", TLSVersion: VersionTLS13, CipherSuites: []uint16{3, 1, 2}, Extensions: []uint16{5, 4}})
+	if a != b {
+		t.Errorf("JA4 changed with cipher/extension order: %q vs %q", a, b)
+	}
+}
+
+func TestCalculateJA4NoALPNUsesZeroCode(t *testing.T) {
+	ja4 := CalculateJA4(JA4Params{Protocol: "t", TLSVersion: VersionTLS12})
+	if !strings.HasPrefix(ja4, "t12i000000") {
+		t.Errorf("ja4 = %q, want prefix t12i000000 (no SNI, no ciphers/extensions, no ALPN)", ja4)
+	}
+}
+
+func TestCalculateJA4RawListsSortedCiphersAndExtensions(t *testing.T) {
+	ja4r := CalculateJA4Raw(JA4Params{
+		Protocol:     "t",
+		TLSVersion:   VersionTLS13,
+		SNIPresent:   true,
+		CipherSuites: []uint16{0x0a0a, 4867, 4865, 4866},
+		Extensions:   []uint16{0x2a2a, 65281, 0, 23},
+		ALPN:         "h2",
+	})
+
+	parts := strings.Split(ja4r, "_")
+	if len(parts) != 3 {
+		t.Fatalf("got %d underscore-separated parts, want 3: %q", len(parts), ja4r)
+	}
+	if parts[0] != "t13d0303h2" {
+		t.Errorf("prefix = %q, want t13d0303h2 (same as CalculateJA4)", parts[0])
+	}
+	if parts[1] != "4865,4866,4867" {
+		t.Errorf("cipher segment = %q, want sorted GREASE-filtered decimal CSV 4865,4866,4867", parts[1])
+	}
+	if parts[2] != "0,23,65281" {
+		t.Errorf("extension segment = %q, want sorted GREASE-filtered decimal CSV 0,23,65281", parts[2])
+	}
+}
+
+func TestCalculateJA4RawSharesPrefixWithHashedForm(t *testing.T) {
+	p := JA4Params{Protocol: "t", TLSVersion: VersionTLS12, CipherSuites: []uint16{1, 2}, Extensions: []uint16{3}}
+	hashed := CalculateJA4(p)
+	raw := CalculateJA4Raw(p)
+	if strings.SplitN(hashed, "_", 2)[0] != strings.SplitN(raw, "_", 2)[0] {
+		t.Errorf("prefixes differ: hashed=%q raw=%q", hashed, raw)
+	}
+}
@@ -0,0 +1,76 @@
+// Package tlsfp holds the TLS/HTTP2 fingerprint presets and helpers (JA3, JA4,
+// Akamai) shared by the HttpCall backend and its tooling.
+package tlsfp
+
+import (
+	"fmt"
+	"time"
+)
+
+// Preset bundles the JA3 and Akamai strings that describe a specific, real
+// browser's fingerprint for reporting and comparison purposes (e.g.
+// CalculateJA3Hash, FreshnessWarning). It does NOT alter the actual
+// ClientHello bytes a connection sends: this module has no uTLS (or other
+// ClientHello-byte-level) dependency, and dialTLS drives Go's standard
+// crypto/tls with only ALPN, curve-preference order, SNI, and
+// renegotiation support derived from a TlsConfig - cipher suite list,
+// extension order, and GREASE on the wire are whatever crypto/tls itself
+// produces, not what JA3/Akamai describe. A preset makes HttpCall self-report
+// (and let a caller compare against) a browser's fingerprint string; it does
+// not make the live handshake match one.
+type Preset struct {
+	Name   string
+	JA3    string
+	Akamai string
+	// ReleaseDate is roughly when the browser version this preset
+	// impersonates shipped. Browsers auto-update, so the longer ago this
+	// was, the more likely the real browser population has moved past it -
+	// see FreshnessWarning. Zero means unknown, and is never flagged stale.
+	ReleaseDate time.Time
+}
+
+// StalePresetAge is how old a preset's ReleaseDate can get before
+// FreshnessWarning starts flagging it - chosen with slack well past a
+// browser's typical release cadence (Chrome ships a new major version
+// roughly every 4 weeks, Firefox every 4-8), so a stale warning means the
+// preset has likely fallen multiple versions behind.
+const StalePresetAge = 120 * 24 * time.Hour
+
+// Presets holds the named browser fingerprints the app and CLI tools can pick
+// from. JA3 and Akamai strings follow the conventional comma/dash encodings.
+var Presets = map[string]Preset{
+	"chrome120": {
+		Name:        "chrome120",
+		JA3:         "771,4865-4866-4867-49195-49199-49196-49200-52393-52392-49171-49172-156-157-47-53,0-23-65281-10-11-35-16-5-13-18-51-45-43-27-17513,29-23-24,0",
+		Akamai:      "1:65536,2:0,4:6291456,6:262144|15663105|0|m,a,s,p",
+		ReleaseDate: time.Date(2023, time.November, 14, 0, 0, 0, 0, time.UTC),
+	},
+	"firefox120": {
+		Name:        "firefox120",
+		JA3:         "771,4865-4867-4866-49195-49199-52393-52392-49196-49200-49162-49161-49171-49172-156-157-47-53,0-23-65281-10-11-16-5-34-51-43-13-28-65037,29-23-24-25-256-257,0",
+		Akamai:      "1:65536,4:131072,5:16384|12517377|3:0:0:201,5:0:0:101|m,p,a,s",
+		ReleaseDate: time.Date(2023, time.November, 21, 0, 0, 0, 0, time.UTC),
+	},
+}
+
+// FreshnessWarning returns a human-readable warning if preset's impersonated
+// browser version is old enough (see StalePresetAge) that a real instance of
+// that browser would have auto-updated past it by now, making the
+// fingerprint detectably stale. Returns "" when preset still looks current,
+// or has no ReleaseDate to judge.
+func FreshnessWarning(preset Preset, now time.Time) string {
+	if preset.ReleaseDate.IsZero() {
+		return ""
+	}
+	age := now.Sub(preset.ReleaseDate)
+	if age <= StalePresetAge {
+		return ""
+	}
+	return fmt.Sprintf("preset %q impersonates a browser version released %s ago; real browsers auto-update, so this fingerprint may now be stale and detectable", preset.Name, age.Round(24*time.Hour))
+}
+
+// Lookup returns the preset registered under id, and whether it was found.
+func Lookup(id string) (Preset, bool) {
+	p, ok := Presets[id]
+	return p, ok
+}
@@ -0,0 +1,31 @@
+package tlsfp
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFreshnessWarningFlagsOldPreset(t *testing.T) {
+	preset := Preset{Name: "old120", ReleaseDate: time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC)}
+	now := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	if got := FreshnessWarning(preset, now); got == "" {
+		t.Error("FreshnessWarning: got empty string, want a warning for a year-old preset")
+	}
+}
+
+func TestFreshnessWarningSilentForCurrentPreset(t *testing.T) {
+	preset := Preset{Name: "new131", ReleaseDate: time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)}
+	now := time.Date(2024, time.January, 10, 0, 0, 0, 0, time.UTC)
+
+	if got := FreshnessWarning(preset, now); got != "" {
+		t.Errorf("FreshnessWarning = %q, want empty for a recent preset", got)
+	}
+}
+
+func TestFreshnessWarningSilentWithoutReleaseDate(t *testing.T) {
+	preset := Preset{Name: "unknown-age"}
+	if got := FreshnessWarning(preset, time.Now()); got != "" {
+		t.Errorf("FreshnessWarning = %q, want empty when ReleaseDate is unset", got)
+	}
+}